@@ -0,0 +1,167 @@
+package tavern
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrParse is failed to parse the string value.
+	ErrParse = errors.New("tavern: failed to parse the value")
+	// ErrInteger is the number is not an integer.
+	ErrInteger = errors.New("tavern: not an integer")
+	// ErrNotFinite is the number is NaN or an infinity.
+	ErrNotFinite = errors.New("tavern: not a finite number")
+)
+
+// ParseError describes a string value that couldn't be coerced to the type, it matches `ErrParse` via `errors.Is`.
+type ParseError struct {
+	// Type is the name of the expected type (e.g. `int`, `duration`).
+	Type string
+	// Input is the string value that failed to parse.
+	Input string
+	// Err is the underlying error of the parser (e.g. `strconv.ErrSyntax`).
+	Err error
+}
+
+// Error returns the message of the parse error.
+func (e *ParseError) Error() string {
+	return ErrParse.Error() + " as " + e.Type + ": " + strconv.Quote(e.Input)
+}
+
+// Unwrap returns the underlying error of the parser.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the target is `ErrParse`.
+func (e *ParseError) Is(target error) bool {
+	return target == ErrParse
+}
+
+// coercer creates a transformer that parses the string value and passes the parsed value to the later validators.
+// The value is passed as is if it was already the type, and the zero values are left as is if the value was not required.
+// A parsed zero value (e.g. `"0"`, `"false"`) is marked as present, so the later validators don't skip it as an absent value.
+func coercer(code, typ string, params Params, typed func(reflect.Value) bool, parse func(string) (interface{}, error)) Validator {
+	return describe(description{code: code, params: params}, func(ctx context.Context, v interface{}) (context.Context, error) {
+		if isNotRequiredAndZeroValue(ctx, v) {
+			return ctx, nil
+		}
+		v = indirect(v)
+
+		if typed(reflect.ValueOf(v)) {
+			return ctx, nil
+		}
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		parsed, err := parse(k)
+		if err != nil {
+			return ctx, newError(code, &ParseError{Type: typ, Input: k, Err: err}, v, params)
+		}
+		return context.WithValue(transformed(ctx, parsed), keyPresent, true), nil
+	})
+}
+
+// isInteger reports whether the value is an integer.
+func isInteger(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
+
+// WithInt parses the string value as a base 10 `int64` (e.g. `"42"`), so the later validators (e.g. `WithRange`) receive the number.
+func WithInt() Validator {
+	return coercer(CodeInt, "int", nil, isInteger, func(s string) (interface{}, error) {
+		return strconv.ParseInt(s, 10, 64)
+	})
+}
+
+// WithFloat parses the string value as a `float64` (e.g. `"4.2"`), NaN and the infinities are rejected.
+// The values that are already numbers are passed as is, except NaN and the infinities which fail with `ErrNotFinite`.
+func WithFloat() Validator {
+	coerce := coercer(CodeFloat, "float", nil, func(value reflect.Value) bool {
+		_, ok := numberOf(value.Interface())
+		return ok
+	}, func(s string) (interface{}, error) {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, err
+		}
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, strconv.ErrSyntax
+		}
+		return f, nil
+	})
+	return describe(description{code: CodeFloat}, func(ctx context.Context, v interface{}) (context.Context, error) {
+		if n, ok := numberOf(indirect(v)); ok && (n == nil || n.IsInf()) {
+			return ctx, newError(CodeFloat, ErrNotFinite, indirect(v), nil)
+		}
+		return coerce(ctx, v)
+	})
+}
+
+// WithBool parses the string value as a `bool`, the accepted values are the same as `strconv.ParseBool` (e.g. `"1"`, `"true"`, `"F"`).
+func WithBool() Validator {
+	return coercer(CodeBool, "bool", nil, func(value reflect.Value) bool {
+		return value.Kind() == reflect.Bool
+	}, func(s string) (interface{}, error) {
+		return strconv.ParseBool(s)
+	})
+}
+
+// WithTime parses the string value as a `time.Time` with the layout (e.g. `time.RFC3339`).
+func WithTime(layout string) Validator {
+	return coercer(CodeTime, "time", Params{"layout": layout}, func(value reflect.Value) bool {
+		_, ok := value.Interface().(time.Time)
+		return ok
+	}, func(s string) (interface{}, error) {
+		return time.Parse(layout, s)
+	})
+}
+
+// WithDuration parses the string value as a `time.Duration` (e.g. `"1h30m"`), so the later validators (e.g. `WithMaxRangeOf(time.Hour)`) receive the duration.
+func WithDuration() Validator {
+	return coercer(CodeDuration, "duration", nil, func(value reflect.Value) bool {
+		_, ok := value.Interface().(time.Duration)
+		return ok
+	}, func(s string) (interface{}, error) {
+		return time.ParseDuration(s)
+	})
+}
+
+// WithInteger requires the number to be an integer that fits in `int64`, the integral floats (e.g. `42.0` from a decoded JSON) and the `json.Number` are accepted
+// and passed to the later validators as `int64`, so `WithLength` counts the digits of the integer.
+func WithInteger() Validator {
+	return describe(description{code: CodeInteger}, func(ctx context.Context, v interface{}) (context.Context, error) {
+		if isNotRequiredAndZeroValue(ctx, v) {
+			return ctx, nil
+		}
+		v = indirect(v)
+
+		if isInteger(reflect.ValueOf(v)) {
+			return ctx, nil
+		}
+		n, ok := numberOf(v)
+		if !ok {
+			return ctx, wrongType(kindNumber, v)
+		}
+		if n == nil || !n.IsInt() {
+			return ctx, newError(CodeInteger, ErrInteger, v, nil)
+		}
+		i, acc := n.Int64()
+		if acc != big.Exact {
+			return ctx, newError(CodeInteger, ErrInteger, v, nil)
+		}
+		return context.WithValue(transformed(ctx, i), keyPresent, true), nil
+	})
+}
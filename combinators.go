@@ -0,0 +1,242 @@
+package tavern
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// chain runs the validators with the value one by one, it stops at the first failure. The transformed value is passed to the later validators,
+// and the context is kept as is if a validator returned a nil context.
+func chain(ctx context.Context, v interface{}, validators []Validator) (context.Context, error) {
+	for _, j := range validators {
+		next, err := call(ctx, j, v)
+		if next != nil {
+			ctx = next
+		}
+		if err != nil {
+			return ctx, err
+		}
+		v = valueOf(ctx, v)
+	}
+	return ctx, nil
+}
+
+// sortedKeys returns the keys of the map sorted by their formatted strings, so the failures are reported in a stable order.
+func sortedKeys(value reflect.Value) []reflect.Value {
+	keys := value.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+	return keys
+}
+
+// elementContext derives the context for the elements of a collection, the elements are not required even if the collection was,
+// and the transformed value (and it's presence) of the collection is not passed to the elements.
+func elementContext(ctx context.Context) context.Context {
+	return context.WithValue(context.WithValue(context.WithValue(ctx, KeyRequired, nil), KeyValue, nil), keyPresent, nil)
+}
+
+// WithEach requires every element of the slice or the array to pass the validators. The failures are collected into `Errors`
+// and the index of the element is reported in the `Field` of the `ValidationError` (e.g. `tags[2]`).
+func WithEach(validators ...Validator) Validator {
+	return describe(description{code: codeEach, err: checkValidators(validators), children: validators}, func(ctx context.Context, v interface{}) (context.Context, error) {
+		if isNotRequiredAndZeroValue(ctx, v) {
+			return ctx, nil
+		}
+		v = indirect(v)
+
+		value := reflect.ValueOf(v)
+		if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+			return ctx, wrongType(kindList, v)
+		}
+		var errs Errors
+		ectx := elementContext(ctx)
+		for i := 0; i < value.Len(); i++ {
+			e := value.Index(i).Interface()
+			if _, err := chain(ectx, e, validators); err != nil {
+				errs = append(errs, nestErrors(fmt.Sprintf("[%d]", i), e, err)...)
+			}
+		}
+		if len(errs) != 0 {
+			return ctx, errs
+		}
+		return ctx, nil
+	})
+}
+
+// WithKeys requires every key of the map to pass the validators. The failures are collected into `Errors`
+// and the key is reported in the `Field` of the `ValidationError` (e.g. `labels[color]`).
+func WithKeys(validators ...Validator) Validator {
+	return mapValidator(codeKeys, validators, true)
+}
+
+// WithValues requires every value of the map to pass the validators. The failures are collected into `Errors`
+// and the key of the value is reported in the `Field` of the `ValidationError` (e.g. `labels[color]`).
+func WithValues(validators ...Validator) Validator {
+	return mapValidator(codeValues, validators, false)
+}
+
+// mapValidator creates a validator that validates the keys or the values of the map.
+func mapValidator(code string, validators []Validator, keys bool) Validator {
+	return describe(description{code: code, err: checkValidators(validators), children: validators}, func(ctx context.Context, v interface{}) (context.Context, error) {
+		if isNotRequiredAndZeroValue(ctx, v) {
+			return ctx, nil
+		}
+		v = indirect(v)
+
+		value := reflect.ValueOf(v)
+		if value.Kind() != reflect.Map {
+			return ctx, wrongType(kindMap, v)
+		}
+		var errs Errors
+		ectx := elementContext(ctx)
+		for _, key := range sortedKeys(value) {
+			k := key.Interface()
+			e := value.MapIndex(key).Interface()
+			if keys {
+				e = k
+			}
+			if _, err := chain(ectx, e, validators); err != nil {
+				errs = append(errs, nestErrors(fmt.Sprintf("[%v]", k), e, err)...)
+			}
+		}
+		if len(errs) != 0 {
+			return ctx, errs
+		}
+		return ctx, nil
+	})
+}
+
+// checkConditional checks the predicate and the validators of a conditional validator.
+func checkConditional(predicate Predicate, validators []Validator) error {
+	if predicate == nil {
+		return invalidConfig("the predicate is nil")
+	}
+	return checkValidators(validators)
+}
+
+// checkAlternatives checks the validators of a logical combinator, there should be at least one alternative.
+func checkAlternatives(validators []Validator) error {
+	if len(validators) == 0 {
+		return invalidConfig("no alternative was specified")
+	}
+	return checkValidators(validators)
+}
+
+// callAlternative runs the alternative of a logical combinator, the value with a wrong type always fails the alternative
+// instead of panicking, regardless of `PanicOnWrongType`. The other panics are propagated as is.
+func callAlternative(ctx context.Context, validator Validator, v interface{}) (next context.Context, err error) {
+	defer recoverWrongType(ctx, indirect(v), &next, &err)
+	return call(ctx, validator, v)
+}
+
+// Predicate reports whether the conditional validators should be run, it's able to inspect both of the context and the value.
+type Predicate func(ctx context.Context, value interface{}) bool
+
+// FieldEquals creates a predicate that reports whether the value of the other named rule equals to the specified value.
+func FieldEquals(field string, value interface{}) Predicate {
+	return func(ctx context.Context, v interface{}) bool {
+		return equalValues(fieldValue(ctx, field), value)
+	}
+}
+
+// WithWhen runs the validators only when the predicate was true, the context of the validators is passed to the next validators of the rule.
+func WithWhen(predicate Predicate, validators ...Validator) Validator {
+	return describe(description{err: checkConditional(predicate, validators)}, func(ctx context.Context, v interface{}) (context.Context, error) {
+		if !predicate(ctx, v) {
+			return ctx, nil
+		}
+		return chain(ctx, v, validators)
+	})
+}
+
+// WithUnless runs the validators only when the predicate was false, the context of the validators is passed to the next validators of the rule.
+func WithUnless(predicate Predicate, validators ...Validator) Validator {
+	return describe(description{err: checkConditional(predicate, validators)}, func(ctx context.Context, v interface{}) (context.Context, error) {
+		if predicate(ctx, v) {
+			return ctx, nil
+		}
+		return chain(ctx, v, validators)
+	})
+}
+
+// WithAllOf requires the value to pass all the validators, it's useful to group the validators as an alternative of `WithAnyOf` or `WithExactlyOneOf`.
+// It stops and returns the error as is at the first failure.
+func WithAllOf(validators ...Validator) Validator {
+	return describe(description{code: codeAllOf, err: checkValidators(validators), children: validators}, func(ctx context.Context, v interface{}) (context.Context, error) {
+		return chain(ctx, v, validators)
+	})
+}
+
+// WithAnyOf requires the value to pass any of the validators, every alternative receives the same context and the context of the first passed alternative is returned.
+// The failures of the alternatives are reported as the `Causes` of the `ValidationError` when none of them passed, an alternative that doesn't accept the type of the value simply fails.
+func WithAnyOf(validators ...Validator) Validator {
+	return describe(description{code: CodeAnyOf, err: checkAlternatives(validators), children: validators}, func(ctx context.Context, v interface{}) (context.Context, error) {
+		var causes Errors
+		for _, j := range validators {
+			next, err := callAlternative(ctx, j, v)
+			if err == nil {
+				return next, nil
+			}
+			causes = append(causes, err)
+		}
+		verr := newError(CodeAnyOf, ErrAnyOf, indirect(v), nil).(*ValidationError)
+		verr.Causes = causes
+		return ctx, verr
+	})
+}
+
+// WithExactlyOneOf requires the value to pass exactly one of the validators, every alternative receives the same context and the context of the passed alternative is returned.
+// The failures of the alternatives are reported as the `Causes` of the `ValidationError` when none of them passed, and the `passed` parameter is the count of the passed alternatives.
+// An alternative that doesn't accept the type of the value simply fails, and the validation is skipped if the value was not required and a zero value.
+func WithExactlyOneOf(validators ...Validator) Validator {
+	return describe(description{code: CodeExactlyOneOf, err: checkAlternatives(validators), children: validators}, func(ctx context.Context, v interface{}) (context.Context, error) {
+		if isNotRequiredAndZeroValue(ctx, v) {
+			return ctx, nil
+		}
+		var (
+			causes Errors
+			passed int
+			result = ctx
+		)
+		for _, j := range validators {
+			next, err := callAlternative(ctx, j, v)
+			if err != nil {
+				causes = append(causes, err)
+				continue
+			}
+			passed++
+			result = next
+		}
+		if passed == 1 {
+			return result, nil
+		}
+		verr := newError(CodeExactlyOneOf, ErrExactlyOneOf, indirect(v), Params{"passed": passed}).(*ValidationError)
+		if passed == 0 {
+			verr.Causes = causes
+		}
+		return ctx, verr
+	})
+}
+
+// WithNot requires the value to fail the validator (e.g. `WithNot(WithEqual("00000000-0000-0000-0000-000000000000"))`).
+// The context of the validator is discarded, and the validation is skipped if the value was not required and a zero value.
+// The value with a wrong type for the validator fails with `ErrWrongType` instead of passing.
+func WithNot(validator Validator) Validator {
+	return describe(description{code: CodeNot, err: checkValidators([]Validator{validator}), children: []Validator{validator}}, func(ctx context.Context, v interface{}) (context.Context, error) {
+		if isNotRequiredAndZeroValue(ctx, v) {
+			return ctx, nil
+		}
+		_, err := callAlternative(ctx, validator, v)
+		switch {
+		case err == nil:
+			return ctx, newError(CodeNot, ErrNot, indirect(v), nil)
+		case errors.Is(err, ErrWrongType):
+			return ctx, err
+		}
+		return ctx, nil
+	})
+}
@@ -0,0 +1,143 @@
+package tavern
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// description describes the configuration of a built-in validator.
+type description struct {
+	// code is the code of the validator (e.g. `CodeLength`).
+	code string
+	// params are the parameters of the validator.
+	params Params
+	// err is the configuration error of the validator (e.g. the minimum is greater than the maximum), it wraps `ErrInvalidConfig`.
+	err error
+	// children are the nested validators of a combinator (e.g. `WithEach`).
+	children []Validator
+	// schema is the nested schema of `WithObject`.
+	schema *Schema
+	// keywords are the JSON Schema keywords that contributed by `WithJSONSchema`.
+	keywords map[string]interface{}
+}
+
+// The codes of the validators that never fail by themselves, they are only used to describe the validators.
+const (
+	codeEach     = "each"
+	codeKeys     = "keys"
+	codeValues   = "values"
+	codeAllOf    = "all_of"
+	codeObject   = "object"
+	codeDefault  = "default"
+	codeKeywords = "keywords"
+)
+
+// probe is passed to the validators as the value to retrieve their description instead of validating.
+type probe struct {
+	d description
+}
+
+// describe wraps the validator so it reports the description when it was probed,
+// and the configuration error is returned as is without validating if the validator was misconfigured.
+// It's never inlined, so every wrapped validator shares the code pointer of the same closure, see `describedCode`.
+//
+//go:noinline
+func describe(d description, validator Validator) Validator {
+	return func(ctx context.Context, v interface{}) (context.Context, error) {
+		if p, ok := v.(*probe); ok {
+			p.d = d
+			return ctx, nil
+		}
+		if d.err != nil {
+			return ctx, d.err
+		}
+		return validator(ctx, v)
+	}
+}
+
+// describedCode is the code pointer of the validators that wrapped by `describe`, the closures of the same function literal share the same code pointer.
+var describedCode = reflect.ValueOf(describe(description{}, nil)).Pointer()
+
+// describeOf probes the validator for it's description, the description is empty if the validator wasn't wrapped by `describe` (e.g. a custom validator).
+// Only the wrapped validators are probed, the other validators are opaque and never called, so their side effects (e.g. a database lookup) don't run.
+func describeOf(validator Validator) description {
+	if validator == nil || reflect.ValueOf(validator).Pointer() != describedCode {
+		return description{}
+	}
+	p := &probe{}
+	_, _ = validator(context.Background(), p)
+	return p.d
+}
+
+// checkValidators returns the first configuration error of the validators, the nested validators of the combinators (e.g. `WithEach`) are also checked.
+func checkValidators(validators []Validator) error {
+	for _, j := range validators {
+		if j == nil {
+			return invalidConfig("the validator is nil")
+		}
+		if err := describeOf(j).err; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// configError prefixes the configuration error with the name of the rule or the field.
+func configError(name string, err error) error {
+	if err == nil || name == "" {
+		return err
+	}
+	return fmt.Errorf("tavern: field %q: %w", name, err)
+}
+
+// invalidConfig creates a configuration error that wraps `ErrInvalidConfig`.
+func invalidConfig(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidConfig, fmt.Sprintf(format, args...))
+}
+
+// checkLength checks the bounds of a length validator.
+func checkLength(min, max int) error {
+	switch {
+	case min < 0:
+		return invalidConfig("the length %d is negative", min)
+	case max < min:
+		return invalidConfig("the minimum length %d is greater than the maximum length %d", min, max)
+	}
+	return nil
+}
+
+// checkFields checks the names of the other named rules that referenced by a cross-field validator.
+func checkFields(fields ...string) error {
+	if len(fields) == 0 {
+		return invalidConfig("no other field was specified")
+	}
+	for _, v := range fields {
+		if v == "" {
+			return invalidConfig("the name of the other field is empty")
+		}
+	}
+	return nil
+}
+
+// checkValues checks the values of `WithOneOf` and `WithNotOneOf`, there should be at least one value.
+func checkValues(values []interface{}) error {
+	if len(values) == 0 {
+		return invalidConfig("no value was specified")
+	}
+	return nil
+}
+
+// checkRange checks the limits of a range validator, the bounds can't be NaN and the range can't be empty.
+func checkRange(min, max *limit) error {
+	if (min != nil && min.n == nil) || (max != nil && max.n == nil) {
+		return invalidConfig("the bound of the range is NaN")
+	}
+	if min == nil || max == nil {
+		return nil
+	}
+	if c := min.n.Cmp(max.n); c > 0 || (c == 0 && (min.exclusive || max.exclusive)) {
+		return invalidConfig("the range from %s to %s is empty", min.n.Text('g', -1), max.n.Text('g', -1))
+	}
+	return nil
+}
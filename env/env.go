@@ -0,0 +1,86 @@
+// Package env validates the environment variables (or the configuration that loaded from the files) with a tavern schema at the startup,
+// every missing or invalid variable is collected and reported at once instead of failing one by one.
+package env
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/teacat/tavern"
+	"golang.org/x/text/language"
+)
+
+var (
+	// stderr is the writer of the report of `MustValidate`.
+	stderr io.Writer = os.Stderr
+	// exit exits the process after the report of `MustValidate` was written.
+	exit = os.Exit
+)
+
+// Environ returns the environment variables as a map, so it can be validated with a schema that declares the variables with `tavern.Field`.
+func Environ() map[string]string {
+	vars := make(map[string]string)
+	for _, v := range os.Environ() {
+		if k, value, ok := strings.Cut(v, "="); ok {
+			vars[k] = value
+		}
+	}
+	return vars
+}
+
+// Validate validates the environment variables with the schema, every failure is collected into `tavern.Errors`. An unset variable is an absent value,
+// so declare it with `tavern.WithRequired` if it's mandatory. The values are strings, use the coercers (e.g. `tavern.WithInt`, `tavern.WithDuration`) for the other types.
+func Validate(schema *tavern.Schema) error {
+	return ValidateValues(schema, Environ())
+}
+
+// ValidateValues is the same as `Validate` but validates the values (e.g. a `map[string]string` or a decoded configuration file) instead of the environment variables.
+func ValidateValues(schema *tavern.Schema, values interface{}) error {
+	return schema.ValidateAll(values)
+}
+
+// MustValidate validates the environment variables with the schema, it writes the report to the standard error and exits with the status 1 if the validation was failed.
+func MustValidate(schema *tavern.Schema) {
+	if err := Validate(schema); err != nil {
+		Report(stderr, err)
+		exit(1)
+	}
+}
+
+// Report writes a readable report of the error, every failed variable is listed with the reason in a line.
+// The invalid values are never written since the variables may be secrets (e.g. `DATABASE_URL` with the password).
+func Report(w io.Writer, err error) {
+	var errs tavern.Errors
+	if !errors.As(err, &errs) {
+		var verr *tavern.ValidationError
+		if !errors.As(err, &verr) {
+			fmt.Fprintf(w, "invalid configuration: %v\n", err)
+			return
+		}
+		errs = tavern.Errors{err}
+	}
+	noun := "variable"
+	if len(errs) > 1 {
+		noun = "variables"
+	}
+	fmt.Fprintf(w, "invalid configuration, %d %s failed the validation:\n", len(errs), noun)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, e := range errs {
+		var verr *tavern.ValidationError
+		if !errors.As(e, &verr) {
+			fmt.Fprintf(tw, "  %s\n", e.Error())
+			continue
+		}
+		reason := tavern.Localize(verr, language.English)
+		if verr.Code == tavern.CodeRequired {
+			reason = "is not set"
+		}
+		fmt.Fprintf(tw, "  %s\t%s\n", verr.Field, reason)
+	}
+	_ = tw.Flush()
+}
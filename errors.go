@@ -0,0 +1,282 @@
+package tavern
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// CodeCustom is the code of the errors that returned by the custom validators.
+	CodeCustom = "custom"
+	// CodeWrongType is the code of the value that passed to a validator with the wrong type.
+	CodeWrongType = "wrong_type"
+	// CodePanic is the code of the recovered panics in the validators.
+	CodePanic = "panic"
+	// CodeRequired is the code of `WithRequired`.
+	CodeRequired = "required"
+	// CodeLength is the code of `WithLength`.
+	CodeLength = "length"
+	// CodeMaxLength is the code of `WithMaxLength`.
+	CodeMaxLength = "max_length"
+	// CodeMinLength is the code of `WithMinLength`.
+	CodeMinLength = "min_length"
+	// CodeFixedLength is the code of `WithFixedLength`.
+	CodeFixedLength = "fixed_length"
+	// CodeRange is the code of `WithRange`.
+	CodeRange = "range"
+	// CodeMaxRange is the code of `WithMaxRange`.
+	CodeMaxRange = "max_range"
+	// CodeMinRange is the code of `WithMinRange`.
+	CodeMinRange = "min_range"
+	// CodeMaximum is the code of `WithMaximum`.
+	CodeMaximum = "maximum"
+	// CodeMinimum is the code of `WithMinimum`.
+	CodeMinimum = "minimum"
+	// CodeDatetime is the code of `WithDatetime`.
+	CodeDatetime = "datetime"
+	// CodeEmail is the code of `WithEmail`.
+	CodeEmail = "email"
+	// CodeRegExp is the code of `WithRegExp`.
+	CodeRegExp = "regexp"
+	// CodePrefix is the code of `WithPrefix`.
+	CodePrefix = "prefix"
+	// CodeSuffix is the code of `WithSuffix`.
+	CodeSuffix = "suffix"
+	// CodeAlpha is the code of `WithAlpha`.
+	CodeAlpha = "alpha"
+	// CodeAlphanumeric is the code of `WithAlphanumeric`.
+	CodeAlphanumeric = "alphanumeric"
+	// CodeAlphaUnicode is the code of `WithAlphaUnicode`.
+	CodeAlphaUnicode = "alpha_unicode"
+	// CodeAlphanumericUnicode is the code of `WithAlphanumericUnicode`.
+	CodeAlphanumericUnicode = "alphanumeric_unicode"
+	// CodeNumeric is the code of `WithNumeric`.
+	CodeNumeric = "numeric"
+	// CodeRGB is the code of `WithRGB`.
+	CodeRGB = "rgb"
+	// CodeRGBA is the code of `WithRGBA`.
+	CodeRGBA = "rgba"
+	// CodeHSL is the code of `WithHSL`.
+	CodeHSL = "hsl"
+	// CodeHSLA is the code of `WithHSLA`.
+	CodeHSLA = "hsla"
+	// CodeJSON is the code of `WithJSON`.
+	CodeJSON = "json"
+	// CodeBase64 is the code of `WithBase64`.
+	CodeBase64 = "base64"
+	// CodeBase64URL is the code of `WithBase64URL`.
+	CodeBase64URL = "base64url"
+	// CodeBitcoinAddress is the code of `WithBitcoinAddress`.
+	CodeBitcoinAddress = "bitcoin_address"
+	// CodeISBN10 is the code of `WithISBN10`.
+	CodeISBN10 = "isbn10"
+	// CodeISBN13 is the code of `WithISBN13`.
+	CodeISBN13 = "isbn13"
+	// CodeUUID is the code of `WithUUID`.
+	CodeUUID = "uuid"
+	// CodeUUID3 is the code of `WithUUID3`.
+	CodeUUID3 = "uuid3"
+	// CodeUUID4 is the code of `WithUUID4`.
+	CodeUUID4 = "uuid4"
+	// CodeUUID5 is the code of `WithUUID5`.
+	CodeUUID5 = "uuid5"
+	// CodeASCII is the code of `WithASCII`.
+	CodeASCII = "ascii"
+	// CodeASCIIPrintable is the code of `WithASCIIPrintable`.
+	CodeASCIIPrintable = "ascii_printable"
+	// CodeMultiByte is the code of `WithMultiByte`.
+	CodeMultiByte = "multibyte"
+	// CodeDataURI is the code of `WithDataURI`.
+	CodeDataURI = "data_uri"
+	// CodeLatitude is the code of `WithLatitude`.
+	CodeLatitude = "latitude"
+	// CodeLongitude is the code of `WithLongitude`.
+	CodeLongitude = "longitude"
+	// CodeTCPAddress is the code of `WithTCPAddress`.
+	CodeTCPAddress = "tcp_address"
+	// CodeTCPv4Address is the code of `WithTCPv4Address`.
+	CodeTCPv4Address = "tcp4_address"
+	// CodeTCPv6Address is the code of `WithTCPv6Address`.
+	CodeTCPv6Address = "tcp6_address"
+	// CodeUDPAddress is the code of `WithUDPAddress`.
+	CodeUDPAddress = "udp_address"
+	// CodeUDPv4Address is the code of `WithUDPv4Address`.
+	CodeUDPv4Address = "udp4_address"
+	// CodeUDPv6Address is the code of `WithUDPv6Address`.
+	CodeUDPv6Address = "udp6_address"
+	// CodeIPAddress is the code of `WithIPAddress`.
+	CodeIPAddress = "ip_address"
+	// CodeIPv4Address is the code of `WithIPv4Address`.
+	CodeIPv4Address = "ip4_address"
+	// CodeIPv6Address is the code of `WithIPv6Address`.
+	CodeIPv6Address = "ip6_address"
+	// CodeUnixAddress is the code of `WithUnixAddress`.
+	CodeUnixAddress = "unix_address"
+	// CodeHTML is the code of `WithHTML`.
+	CodeHTML = "html"
+	// CodeURL is the code of `WithURL`.
+	CodeURL = "url"
+	// CodeEqual is the code of `WithEqual`.
+	CodeEqual = "equal"
+	// CodeOneOf is the code of `WithOneOf`.
+	CodeOneOf = "one_of"
+	// CodeNotOneOf is the code of `WithNotOneOf`.
+	CodeNotOneOf = "not_one_of"
+	// CodeAnyOf is the code of `WithAnyOf`.
+	CodeAnyOf = "any_of"
+	// CodeExactlyOneOf is the code of `WithExactlyOneOf`.
+	CodeExactlyOneOf = "exactly_one_of"
+	// CodeNot is the code of `WithNot`.
+	CodeNot = "not"
+	// CodeInt is the code of `WithInt`.
+	CodeInt = "int"
+	// CodeFloat is the code of `WithFloat`.
+	CodeFloat = "float"
+	// CodeBool is the code of `WithBool`.
+	CodeBool = "bool"
+	// CodeTime is the code of `WithTime`.
+	CodeTime = "time"
+	// CodeDuration is the code of `WithDuration`.
+	CodeDuration = "duration"
+	// CodeInteger is the code of `WithInteger`.
+	CodeInteger = "integer"
+	// CodeUnknownField is the code of the keys that were not declared in a strict `Schema`.
+	CodeUnknownField = "unknown_field"
+	// CodeEqualTo is the code of `WithEqualTo`.
+	CodeEqualTo = "equal_to"
+	// CodeGreaterThanField is the code of `WithGreaterThanField`.
+	CodeGreaterThanField = "greater_than_field"
+	// CodeLessThanField is the code of `WithLessThanField`.
+	CodeLessThanField = "less_than_field"
+	// CodeRequiredIf is the code of `WithRequiredIf`.
+	CodeRequiredIf = "required_if"
+	// CodeRequiredUnless is the code of `WithRequiredUnless`.
+	CodeRequiredUnless = "required_unless"
+	// CodeRequiredWith is the code of `WithRequiredWith`.
+	CodeRequiredWith = "required_with"
+	// CodeExcludedWith is the code of `WithExcludedWith`.
+	CodeExcludedWith = "excluded_with"
+)
+
+// Params are the parameters of a validator (e.g. `min`, `max`, `pattern`, `format`).
+type Params map[string]interface{}
+
+// ValidationError describes a failed validation with the name of the rule, a stable machine code, the parameters of the validator and the invalid value.
+// It wraps the underlying error (e.g. `ErrLength`) so it's still comparable via `errors.Is`.
+type ValidationError struct {
+	// Field is the name of the rule, it's empty if the rule wasn't named.
+	Field string
+	// Code is the stable machine code of the validator (e.g. `length`, `email`).
+	Code string
+	// Params are the parameters of the validator.
+	Params Params
+	// Value is the value that failed the validation.
+	Value interface{}
+	// Err is the underlying error.
+	Err error
+	// Causes are the failures of the composed validators (e.g. the failed alternatives of `WithAnyOf`).
+	Causes Errors
+}
+
+// Error returns the message of the underlying error, prefixed with the field name if the rule was named. The causes are appended in the parentheses.
+func (e *ValidationError) Error() string {
+	msg := e.Err.Error()
+	if len(e.Causes) != 0 {
+		msg += " (" + e.Causes.Error() + ")"
+	}
+	if e.Field == "" {
+		return msg
+	}
+	return e.Field + ": " + msg
+}
+
+// Unwrap returns the underlying error.
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// newError creates a `ValidationError` for the built-in validators.
+func newError(code string, err error, v interface{}, params Params) error {
+	return &ValidationError{
+		Code:   code,
+		Params: params,
+		Value:  v,
+		Err:    err,
+	}
+}
+
+// recoveredError converts the recovered panic of a validator to a `ValidationError`.
+func recoveredError(r interface{}, v interface{}) error {
+	if err, ok := r.(error); ok && errors.Is(err, ErrWrongType) {
+		var (
+			werr   *wrongTypeError
+			params Params
+		)
+		if errors.As(err, &werr) {
+			params = werr.params
+		}
+		return newError(CodeWrongType, ErrWrongType, v, params)
+	}
+	return newError(CodePanic, fmt.Errorf("%w: %v", ErrPanic, r), v, nil)
+}
+
+// toValidationError converts the error that returned by a validator to a `ValidationError`, the field is prefixed with the path.
+// A wrapped `ValidationError` (e.g. `fmt.Errorf("...: %w", verr)`) keeps it's code and parameters, and the message of the wrapping error is kept.
+func toValidationError(path string, v interface{}, err error) *ValidationError {
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		return &ValidationError{Field: path, Code: CodeCustom, Value: v, Err: err}
+	}
+	cp := *verr
+	cp.Field = joinPath(path, cp.Field)
+	if err != error(verr) {
+		cp.Err = err
+	}
+	return &cp
+}
+
+// nestErrors converts the error that returned by a validator to the validation errors with the path, the `Errors` is flattened.
+func nestErrors(path string, v interface{}, err error) Errors {
+	errs, ok := err.(Errors)
+	if !ok {
+		return Errors{toValidationError(path, v, err)}
+	}
+	nested := make(Errors, len(errs))
+	for i, e := range errs {
+		nested[i] = toValidationError(path, v, e)
+	}
+	return nested
+}
+
+// Errors is a set of the validation failures that returned by `ValidateAll`. It can be inspected by `errors.Is` and `errors.As`, the set matches the target if any of the errors matches.
+type Errors []error
+
+// Error joins all the error messages with a semicolon.
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, v := range e {
+		msgs[i] = v.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Is reports whether any error in the set matches the target.
+func (e Errors) Is(target error) bool {
+	for _, v := range e {
+		if errors.Is(v, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first error in the set that matches the target, and if so, sets the target to that error value and returns true.
+func (e Errors) As(target interface{}) bool {
+	for _, v := range e {
+		if errors.As(v, target) {
+			return true
+		}
+	}
+	return false
+}
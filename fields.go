@@ -0,0 +1,169 @@
+package tavern
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// fieldValue returns the value of the named rule in the same validation, it returns nil if the rule wasn't found.
+func fieldValue(ctx context.Context, field string) interface{} {
+	values, _ := ctx.Value(KeyValues).(map[string]interface{})
+	return values[field]
+}
+
+// isPresent reports whether the value is neither absent nor a zero value.
+func isPresent(v interface{}) bool {
+	v = indirect(v)
+	return v != nil && !reflect.ValueOf(v).IsZero()
+}
+
+// compareValues compares the numbers, the strings or the `time.Time` values, it returns false if the values are not comparable.
+func compareValues(a, b interface{}) (int, bool) {
+	a, b = indirect(a), indirect(b)
+	if ta, ok := a.(time.Time); ok {
+		tb, ok := b.(time.Time)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case ta.Before(tb):
+			return -1, true
+		case ta.After(tb):
+			return 1, true
+		}
+		return 0, true
+	}
+	if na, ok := numberOf(a); ok {
+		nb, ok := numberOf(b)
+		if !ok || na == nil || nb == nil {
+			return 0, false
+		}
+		return na.Cmp(nb), true
+	}
+	if sa, ok := stringOf(a); ok {
+		sb, ok := stringOf(b)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(sa, sb), true
+	}
+	return 0, false
+}
+
+// equalValues reports whether the values are equal, the values that are not comparable by `compareValues` are compared deeply.
+func equalValues(a, b interface{}) bool {
+	if c, ok := compareValues(a, b); ok {
+		return c == 0
+	}
+	return reflect.DeepEqual(indirect(a), indirect(b))
+}
+
+// WithEqualTo requires the value to be equal to the value of the other named rule (e.g. `password_confirm` equals to `password`).
+func WithEqualTo(field string) Validator {
+	return describe(description{code: CodeEqualTo, params: Params{"other": field}, err: checkFields(field)}, func(ctx context.Context, v interface{}) (context.Context, error) {
+		if !equalValues(v, fieldValue(ctx, field)) {
+			return ctx, newError(CodeEqualTo, ErrNotEqual, indirect(v), Params{"other": field})
+		}
+		return ctx, nil
+	})
+}
+
+// WithGreaterThanField requires the value to be greater than the value of the other named rule, it compares the numbers, the strings and the `time.Time` values.
+// The validation is skipped if the other value was absent.
+func WithGreaterThanField(field string) Validator {
+	return compareField(CodeGreaterThanField, field, func(c int) bool {
+		return c > 0
+	})
+}
+
+// WithLessThanField requires the value to be less than the value of the other named rule, it compares the numbers, the strings and the `time.Time` values.
+// The validation is skipped if the other value was absent.
+func WithLessThanField(field string) Validator {
+	return compareField(CodeLessThanField, field, func(c int) bool {
+		return c < 0
+	})
+}
+
+// compareField creates a validator that compares the value with the value of the other named rule.
+func compareField(code string, field string, pass func(c int) bool) Validator {
+	return describe(description{code: code, params: Params{"other": field}, err: checkFields(field)}, func(ctx context.Context, v interface{}) (context.Context, error) {
+		if isNotRequiredAndZeroValue(ctx, v) {
+			return ctx, nil
+		}
+		v = indirect(v)
+
+		other := indirect(fieldValue(ctx, field))
+		if other == nil {
+			return ctx, nil
+		}
+		c, ok := compareValues(v, other)
+		if !ok {
+			return ctx, wrongType(kindComparable, v)
+		}
+		if !pass(c) {
+			return ctx, newError(code, ErrComparison, v, Params{"other": field})
+		}
+		return ctx, nil
+	})
+}
+
+// WithRequiredIf requires the value to be present when the value of the other named rule equals to the specified value (e.g. `state` is required when `country` is `US`).
+func WithRequiredIf(field string, value interface{}) Validator {
+	return describe(description{code: CodeRequiredIf, params: Params{"other": field, "other_value": value}, err: checkFields(field)}, func(ctx context.Context, v interface{}) (context.Context, error) {
+		if !equalValues(fieldValue(ctx, field), value) {
+			return ctx, nil
+		}
+		return requireValue(ctx, v, CodeRequiredIf, Params{"other": field, "other_value": value})
+	})
+}
+
+// WithRequiredUnless requires the value to be present unless the value of the other named rule equals to the specified value.
+func WithRequiredUnless(field string, value interface{}) Validator {
+	return describe(description{code: CodeRequiredUnless, params: Params{"other": field, "other_value": value}, err: checkFields(field)}, func(ctx context.Context, v interface{}) (context.Context, error) {
+		if equalValues(fieldValue(ctx, field), value) {
+			return ctx, nil
+		}
+		return requireValue(ctx, v, CodeRequiredUnless, Params{"other": field, "other_value": value})
+	})
+}
+
+// WithRequiredWith requires the value to be present when any of the values of the other named rules is present.
+func WithRequiredWith(fields ...string) Validator {
+	return describe(description{code: CodeRequiredWith, params: Params{"others": strings.Join(fields, ", ")}, err: checkFields(fields...)}, func(ctx context.Context, v interface{}) (context.Context, error) {
+		if !anyPresent(ctx, fields) {
+			return ctx, nil
+		}
+		return requireValue(ctx, v, CodeRequiredWith, Params{"others": strings.Join(fields, ", ")})
+	})
+}
+
+// WithExcludedWith requires the value to be absent when any of the values of the other named rules is present.
+func WithExcludedWith(fields ...string) Validator {
+	return describe(description{code: CodeExcludedWith, params: Params{"others": strings.Join(fields, ", ")}, err: checkFields(fields...)}, func(ctx context.Context, v interface{}) (context.Context, error) {
+		if anyPresent(ctx, fields) && isPresent(v) {
+			return ctx, newError(CodeExcludedWith, ErrExcluded, indirect(v), Params{"others": strings.Join(fields, ", ")})
+		}
+		return ctx, nil
+	})
+}
+
+// anyPresent reports whether any of the values of the named rules is present.
+func anyPresent(ctx context.Context, fields []string) bool {
+	for _, v := range fields {
+		if isPresent(fieldValue(ctx, v)) {
+			return true
+		}
+	}
+	return false
+}
+
+// requireValue marks the value as required like `WithRequired`, and returns an error with the code if the value was absent.
+func requireValue(ctx context.Context, v interface{}, code string, params Params) (context.Context, error) {
+	ctx = context.WithValue(ctx, KeyRequired, true)
+	if !isPresent(v) {
+		return ctx, newError(code, ErrRequired, indirect(v), params)
+	}
+	return ctx, nil
+}
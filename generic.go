@@ -0,0 +1,189 @@
+package tavern
+
+// StringRule builds a `Rule` for a string value, only the validators that accept a string are available so the misuse is a compile-time error.
+type StringRule[T ~string] struct {
+	rule Rule
+}
+
+// String creates a rule builder for the string value (e.g. `String(v).Required().MinLen(3).Email().Rule()`).
+func String[T ~string](v T) *StringRule[T] {
+	return &StringRule[T]{rule: NewRule(v)}
+}
+
+// Named sets the name of the rule, it will be reported as the `Field` of the `ValidationError`.
+func (r *StringRule[T]) Named(name string) *StringRule[T] {
+	r.rule.name = name
+	return r
+}
+
+// Exhaustive keeps running the rest of the validators after one was failed, see `Rule.Exhaustive`.
+func (r *StringRule[T]) Exhaustive() *StringRule[T] {
+	r.rule.exhaustive = true
+	return r
+}
+
+// With appends the validators to the rule, it's the escape hatch for the validators that don't have a method and it's not type checked.
+func (r *StringRule[T]) With(validators ...Validator) *StringRule[T] {
+	r.rule.validators = append(r.rule.validators, validators...)
+	return r
+}
+
+// Required appends `WithRequired`.
+func (r *StringRule[T]) Required() *StringRule[T] { return r.With(WithRequired()) }
+
+// Len appends `WithLength`.
+func (r *StringRule[T]) Len(min, max int) *StringRule[T] { return r.With(WithLength(min, max)) }
+
+// MinLen appends `WithMinLength`.
+func (r *StringRule[T]) MinLen(min int) *StringRule[T] { return r.With(WithMinLength(min)) }
+
+// MaxLen appends `WithMaxLength`.
+func (r *StringRule[T]) MaxLen(max int) *StringRule[T] { return r.With(WithMaxLength(max)) }
+
+// FixedLen appends `WithFixedLength`.
+func (r *StringRule[T]) FixedLen(length int) *StringRule[T] { return r.With(WithFixedLength(length)) }
+
+// Email appends `WithEmail`.
+func (r *StringRule[T]) Email() *StringRule[T] { return r.With(WithEmail()) }
+
+// Match appends `WithRegExp`.
+func (r *StringRule[T]) Match(pattern string) *StringRule[T] { return r.With(WithRegExp(pattern)) }
+
+// Prefix appends `WithPrefix`.
+func (r *StringRule[T]) Prefix(p string) *StringRule[T] { return r.With(WithPrefix(p)) }
+
+// Suffix appends `WithSuffix`.
+func (r *StringRule[T]) Suffix(s string) *StringRule[T] { return r.With(WithSuffix(s)) }
+
+// Alpha appends `WithAlpha`.
+func (r *StringRule[T]) Alpha() *StringRule[T] { return r.With(WithAlpha()) }
+
+// Alphanumeric appends `WithAlphanumeric`.
+func (r *StringRule[T]) Alphanumeric() *StringRule[T] { return r.With(WithAlphanumeric()) }
+
+// Numeric appends `WithNumeric`.
+func (r *StringRule[T]) Numeric() *StringRule[T] { return r.With(WithNumeric()) }
+
+// UUID appends `WithUUID`.
+func (r *StringRule[T]) UUID() *StringRule[T] { return r.With(WithUUID()) }
+
+// Datetime appends `WithDatetime`.
+func (r *StringRule[T]) Datetime(f string) *StringRule[T] { return r.With(WithDatetime(f)) }
+
+// Equal appends `WithEqual`.
+func (r *StringRule[T]) Equal(value T) *StringRule[T] { return r.With(WithEqual(value)) }
+
+// Trim appends `WithTrim`.
+func (r *StringRule[T]) Trim() *StringRule[T] { return r.With(WithTrim()) }
+
+// Lowercase appends `WithLowercase`.
+func (r *StringRule[T]) Lowercase() *StringRule[T] { return r.With(WithLowercase()) }
+
+// Rule returns the built rule, it should be passed to `Validate`.
+func (r *StringRule[T]) Rule() Rule {
+	return r.rule.clone()
+}
+
+// NumberRule builds a `Rule` for a number value, the bounds have the same type as the value so the lossy conversions are not possible.
+type NumberRule[T Numeric] struct {
+	rule Rule
+}
+
+// Number creates a rule builder for the number value (e.g. `Number(age).Between(18, 130).Rule()`).
+func Number[T Numeric](v T) *NumberRule[T] {
+	return &NumberRule[T]{rule: NewRule(v)}
+}
+
+// Named sets the name of the rule, it will be reported as the `Field` of the `ValidationError`.
+func (r *NumberRule[T]) Named(name string) *NumberRule[T] {
+	r.rule.name = name
+	return r
+}
+
+// Exhaustive keeps running the rest of the validators after one was failed, see `Rule.Exhaustive`.
+func (r *NumberRule[T]) Exhaustive() *NumberRule[T] {
+	r.rule.exhaustive = true
+	return r
+}
+
+// With appends the validators to the rule, it's the escape hatch for the validators that don't have a method and it's not type checked.
+func (r *NumberRule[T]) With(validators ...Validator) *NumberRule[T] {
+	r.rule.validators = append(r.rule.validators, validators...)
+	return r
+}
+
+// Required appends `WithRequired`.
+func (r *NumberRule[T]) Required() *NumberRule[T] { return r.With(WithRequired()) }
+
+// Between appends `WithRangeOf`.
+func (r *NumberRule[T]) Between(min, max T, bounds ...Bound) *NumberRule[T] {
+	return r.With(WithRangeOf(min, max, bounds...))
+}
+
+// Min appends `WithMinRangeOf`.
+func (r *NumberRule[T]) Min(min T, bounds ...Bound) *NumberRule[T] {
+	return r.With(WithMinRangeOf(min, bounds...))
+}
+
+// Max appends `WithMaxRangeOf`.
+func (r *NumberRule[T]) Max(max T, bounds ...Bound) *NumberRule[T] {
+	return r.With(WithMaxRangeOf(max, bounds...))
+}
+
+// Equal appends `WithEqual`.
+func (r *NumberRule[T]) Equal(value T) *NumberRule[T] { return r.With(WithEqual(value)) }
+
+// Rule returns the built rule, it should be passed to `Validate`.
+func (r *NumberRule[T]) Rule() Rule {
+	return r.rule.clone()
+}
+
+// SliceRule builds a `Rule` for a slice value.
+type SliceRule[T any] struct {
+	rule Rule
+}
+
+// Slice creates a rule builder for the slice value (e.g. `Slice(tags).MaxLen(5).Each(WithAlpha()).Rule()`).
+func Slice[T any](v []T) *SliceRule[T] {
+	return &SliceRule[T]{rule: NewRule(v)}
+}
+
+// Named sets the name of the rule, it will be reported as the `Field` of the `ValidationError`.
+func (r *SliceRule[T]) Named(name string) *SliceRule[T] {
+	r.rule.name = name
+	return r
+}
+
+// Exhaustive keeps running the rest of the validators after one was failed, see `Rule.Exhaustive`.
+func (r *SliceRule[T]) Exhaustive() *SliceRule[T] {
+	r.rule.exhaustive = true
+	return r
+}
+
+// With appends the validators to the rule, it's the escape hatch for the validators that don't have a method and it's not type checked.
+func (r *SliceRule[T]) With(validators ...Validator) *SliceRule[T] {
+	r.rule.validators = append(r.rule.validators, validators...)
+	return r
+}
+
+// Required appends `WithRequired`.
+func (r *SliceRule[T]) Required() *SliceRule[T] { return r.With(WithRequired()) }
+
+// Len appends `WithLength`.
+func (r *SliceRule[T]) Len(min, max int) *SliceRule[T] { return r.With(WithLength(min, max)) }
+
+// MinLen appends `WithMinLength`.
+func (r *SliceRule[T]) MinLen(min int) *SliceRule[T] { return r.With(WithMinLength(min)) }
+
+// MaxLen appends `WithMaxLength`.
+func (r *SliceRule[T]) MaxLen(max int) *SliceRule[T] { return r.With(WithMaxLength(max)) }
+
+// Each appends `WithEach`, the validators are applied to every element.
+func (r *SliceRule[T]) Each(validators ...Validator) *SliceRule[T] {
+	return r.With(WithEach(validators...))
+}
+
+// Rule returns the built rule, it should be passed to `Validate`.
+func (r *SliceRule[T]) Rule() Rule {
+	return r.rule.clone()
+}
@@ -0,0 +1,408 @@
+package tavern
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"regexp"
+	"time"
+)
+
+// JSONSchemaDialect is the `$schema` of the exported JSON Schema documents.
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// patterns are the regular expressions of the built-in validators that exported as the `pattern` keyword.
+var patterns = map[string]string{
+	CodeAlpha:               alphaRegexString,
+	CodeAlphanumeric:        alphaNumericRegexString,
+	CodeAlphaUnicode:        alphaUnicodeRegexString,
+	CodeAlphanumericUnicode: alphaUnicodeNumericRegexString,
+	CodeNumeric:             numericRegexString,
+	CodeRGB:                 rgbRegexString,
+	CodeRGBA:                rgbaRegexString,
+	CodeHSL:                 hslRegexString,
+	CodeHSLA:                hslaRegexString,
+	CodeBase64:              base64RegexString,
+	CodeBase64URL:           base64URLRegexString,
+	CodeBitcoinAddress:      btcAddressRegexString,
+	CodeISBN10:              iSBN10RegexString,
+	CodeISBN13:              iSBN13RegexString,
+	CodeUUID3:               uUID3RegexString,
+	CodeUUID4:               uUID4RegexString,
+	CodeUUID5:               uUID5RegexString,
+	CodeASCII:               aSCIIRegexString,
+	CodeASCIIPrintable:      printableASCIIRegexString,
+	CodeMultiByte:           multibyteRegexString,
+	CodeDataURI:             dataURIRegexString,
+	CodeLatitude:            latitudeRegexString,
+	CodeLongitude:           longitudeRegexString,
+	CodeHTML:                hTMLRegexString,
+}
+
+// formats are the `format` keywords of the built-in validators.
+var formats = map[string]string{
+	CodeEmail:       "email",
+	CodeUUID:        "uuid",
+	CodeUUID3:       "uuid",
+	CodeUUID4:       "uuid",
+	CodeUUID5:       "uuid",
+	CodeIPv4Address: "ipv4",
+	CodeIPv6Address: "ipv6",
+	CodeURL:         "uri",
+}
+
+// timeFormats are the `format` keywords of the well-known time layouts.
+var timeFormats = map[string]string{
+	time.RFC3339:     "date-time",
+	time.RFC3339Nano: "date-time",
+	"2006-01-02":     "date",
+	"15:04:05":       "time",
+}
+
+// JSONSchema exports the named rules as a JSON Schema (Draft 2020-12) document of an object, the named rules are the properties of the object
+// and the unnamed rules are ignored. The keywords are derived from the built-in validators (e.g. `WithLength` as `minLength` and `maxLength`),
+// the type of the property is inferred from the value and the validators. The custom validators contribute their keywords with `WithJSONSchema`.
+func JSONSchema(rules ...Rule) map[string]interface{} {
+	props := make([]property, 0, len(rules))
+	for _, r := range rules {
+		if r.name != "" {
+			props = append(props, property{name: r.name, value: r.value, validators: r.validators})
+		}
+	}
+	doc := objectSchema(props, false)
+	doc["$schema"] = JSONSchemaDialect
+	return doc
+}
+
+// JSONSchema exports the schema as a JSON Schema (Draft 2020-12) document of an object, see the package function `JSONSchema`.
+// A strict schema disallows the additional properties.
+func (s *Schema) JSONSchema() map[string]interface{} {
+	doc := s.jsonSchema()
+	doc["$schema"] = JSONSchemaDialect
+	return doc
+}
+
+// jsonSchema converts the schema to a JSON Schema object without the `$schema`.
+func (s *Schema) jsonSchema() map[string]interface{} {
+	props := make([]property, len(s.fields))
+	for i, f := range s.fields {
+		props[i] = property{name: f.name, validators: f.validators, required: f.required}
+	}
+	return objectSchema(props, s.strict)
+}
+
+// WithJSONSchema contributes the JSON Schema keywords (e.g. `{"format": "hostname"}`) to the validator, so a custom validator can be exported by `JSONSchema`.
+// The keywords override the keywords of the validator itself.
+func WithJSONSchema(validator Validator, keywords map[string]interface{}) Validator {
+	return describe(description{code: codeKeywords, err: checkValidators([]Validator{validator}), children: []Validator{validator}, keywords: keywords}, validator)
+}
+
+// property is a property of an exported object.
+type property struct {
+	name       string
+	value      interface{}
+	validators []Validator
+	// required requires the property to be present even if it has no `WithRequired`.
+	required bool
+}
+
+// objectSchema converts the properties to a JSON Schema object.
+func objectSchema(props []property, strict bool) map[string]interface{} {
+	properties := make(map[string]interface{}, len(props))
+	required := []string{}
+	for _, p := range props {
+		b := newSchemaBuilder(p.value)
+		b.apply(p.validators)
+		properties[p.name] = b.build()
+		if p.required || b.required {
+			required = append(required, p.name)
+		}
+	}
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) != 0 {
+		schema["required"] = required
+	}
+	if strict {
+		schema["additionalProperties"] = false
+	}
+	return schema
+}
+
+// schemaBuilder collects the keywords of the validators, the keywords that depend on the type are resolved when it's built.
+type schemaBuilder struct {
+	schema map[string]interface{}
+	// typ is the JSON type of the value, it's inferred from the value unless a validator has declared the type.
+	typ string
+	// required reports whether the value is required by `WithRequired`.
+	required bool
+	// patterns are the patterns of the validators.
+	patterns []string
+	// minLength and maxLength are the length bounds, they are exported as the keywords of the type (e.g. `minItems` of an array).
+	minLength, maxLength *int
+	// min and max are the parameters of `WithMinimum` and `WithMaximum` that bound the length or the number by the type.
+	min, max Params
+}
+
+// newSchemaBuilder creates a builder with the type inferred from the value, the value can be nil.
+func newSchemaBuilder(v interface{}) *schemaBuilder {
+	return &schemaBuilder{schema: make(map[string]interface{}), typ: jsonTypeOf(v)}
+}
+
+// jsonTypeOf infers the JSON type of the value, it's empty if the value was nil.
+func jsonTypeOf(v interface{}) string {
+	v = indirect(v)
+	switch v.(type) {
+	case nil:
+		return ""
+	case json.Number:
+		return "number"
+	case time.Time:
+		return "string"
+	}
+	value := reflect.ValueOf(v)
+	switch value.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return "string"
+		}
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	}
+	return ""
+}
+
+// declare declares the type of the value, the inferred type is overridden.
+func (b *schemaBuilder) declare(typ string) {
+	b.typ = typ
+}
+
+// infer sets the type of the value if it was unknown.
+func (b *schemaBuilder) infer(typ string) {
+	if b.typ == "" {
+		b.typ = typ
+	}
+}
+
+// apply collects the keywords of the validators.
+func (b *schemaBuilder) apply(validators []Validator) {
+	for _, j := range validators {
+		b.describe(describeOf(j))
+	}
+}
+
+// describe collects the keywords of the described validator, the validators without a description (e.g. the custom validators) are ignored.
+func (b *schemaBuilder) describe(d description) {
+	if pattern, ok := patterns[d.code]; ok {
+		b.infer("string")
+		b.patterns = append(b.patterns, pattern)
+	}
+	if format, ok := formats[d.code]; ok {
+		b.infer("string")
+		b.schema["format"] = format
+	}
+
+	switch d.code {
+	case CodeRequired:
+		b.required = true
+	case CodeLength:
+		b.minLength, b.maxLength = intParam(d.params, "min"), intParam(d.params, "max")
+	case CodeMinLength:
+		b.minLength = intParam(d.params, "min")
+	case CodeMaxLength:
+		b.maxLength = intParam(d.params, "max")
+	case CodeFixedLength:
+		b.minLength, b.maxLength = intParam(d.params, "length"), intParam(d.params, "length")
+	case CodeMinimum:
+		b.min = d.params
+	case CodeMaximum:
+		b.max = d.params
+	case CodeRange, CodeMinRange, CodeMaxRange:
+		b.infer("number")
+		b.bound("min", "minimum", "exclusiveMinimum", d.params)
+		b.bound("max", "maximum", "exclusiveMaximum", d.params)
+	case CodeRegExp:
+		b.infer("string")
+		b.patterns = append(b.patterns, d.params["pattern"].(string))
+	case CodePrefix:
+		b.infer("string")
+		b.patterns = append(b.patterns, "^"+regexp.QuoteMeta(d.params["prefix"].(string)))
+	case CodeSuffix:
+		b.infer("string")
+		b.patterns = append(b.patterns, regexp.QuoteMeta(d.params["suffix"].(string))+"$")
+	case CodeDatetime:
+		b.infer("string")
+		if format, ok := timeFormats[d.params["format"].(string)]; ok {
+			b.schema["format"] = format
+		}
+	case CodeJSON:
+		b.infer("string")
+		b.schema["contentMediaType"] = "application/json"
+	case CodeEqual:
+		b.schema["const"] = d.params["expected"]
+	case CodeOneOf:
+		b.schema["enum"] = d.params["values"]
+	case CodeNotOneOf:
+		b.schema["not"] = map[string]interface{}{"enum": d.params["values"]}
+	case CodeInt, CodeInteger:
+		b.declare("integer")
+	case CodeFloat:
+		b.declare("number")
+	case CodeBool:
+		b.declare("boolean")
+	case CodeTime:
+		b.declare("string")
+		if format, ok := timeFormats[d.params["layout"].(string)]; ok {
+			b.schema["format"] = format
+		}
+	case CodeDuration:
+		b.declare("string")
+	case codeDefault:
+		b.schema["default"] = d.params["value"]
+	case codeEach:
+		b.infer("array")
+		b.schema["items"] = childSchema(d.children)
+	case codeKeys:
+		b.infer("object")
+		b.schema["propertyNames"] = childSchema(d.children)
+	case codeValues:
+		b.infer("object")
+		b.schema["additionalProperties"] = childSchema(d.children)
+	case codeObject:
+		b.declare("object")
+		for k, v := range d.schema.jsonSchema() {
+			b.schema[k] = v
+		}
+	case codeAllOf:
+		b.apply(d.children)
+	case CodeAnyOf:
+		b.schema["anyOf"] = childSchemas(d.children)
+	case CodeExactlyOneOf:
+		b.schema["oneOf"] = childSchemas(d.children)
+	case CodeNot:
+		b.schema["not"] = childSchema(d.children)
+	case codeKeywords:
+		b.apply(d.children)
+		for k, v := range d.keywords {
+			b.schema[k] = v
+		}
+	}
+}
+
+// bound exports the bound of a range validator as the inclusive or the exclusive keyword.
+func (b *schemaBuilder) bound(param, inclusive, exclusive string, params Params) {
+	v, ok := params[param]
+	if !ok {
+		return
+	}
+	if params["exclusive_"+param] == true {
+		b.schema[exclusive] = v
+		return
+	}
+	b.schema[inclusive] = v
+}
+
+// build resolves the type dependent keywords and returns the JSON Schema.
+func (b *schemaBuilder) build() map[string]interface{} {
+	if _, ok := b.schema["type"]; !ok && b.typ != "" {
+		b.schema["type"] = b.typ
+	}
+	if len(b.patterns) != 0 {
+		b.schema["pattern"] = b.patterns[0]
+		if len(b.patterns) > 1 {
+			all := make([]interface{}, len(b.patterns)-1)
+			for i, v := range b.patterns[1:] {
+				all[i] = map[string]interface{}{"pattern": v}
+			}
+			b.schema["allOf"] = all
+		}
+	}
+
+	switch b.typ {
+	case "integer", "number":
+		b.bound("min", "minimum", "exclusiveMinimum", b.min)
+		b.bound("max", "maximum", "exclusiveMaximum", b.max)
+	case "array":
+		b.keyword("minItems", b.minLength, lengthParam(b.min, "min"))
+		b.keyword("maxItems", b.maxLength, lengthParam(b.max, "max"))
+	case "object":
+		b.keyword("minProperties", b.minLength, lengthParam(b.min, "min"))
+		b.keyword("maxProperties", b.maxLength, lengthParam(b.max, "max"))
+	default:
+		if b.required && b.typ == "string" && b.minLength == nil && b.min == nil {
+			b.schema["minLength"] = 1
+		}
+		b.keyword("minLength", b.minLength, lengthParam(b.min, "min"))
+		b.keyword("maxLength", b.maxLength, lengthParam(b.max, "max"))
+	}
+	return b.schema
+}
+
+// keyword sets the keyword with the first non-nil value.
+func (b *schemaBuilder) keyword(name string, values ...*int) {
+	for _, v := range values {
+		if v != nil {
+			b.schema[name] = *v
+			return
+		}
+	}
+}
+
+// childSchema converts the nested validators of a combinator to a JSON Schema.
+func childSchema(validators []Validator) map[string]interface{} {
+	b := newSchemaBuilder(nil)
+	b.apply(validators)
+	return b.build()
+}
+
+// childSchemas converts the alternatives of a combinator to the JSON Schemas.
+func childSchemas(validators []Validator) []interface{} {
+	schemas := make([]interface{}, len(validators))
+	for i, v := range validators {
+		schemas[i] = childSchema([]Validator{v})
+	}
+	return schemas
+}
+
+// lengthParam converts the number bound of `WithMinimum` or `WithMaximum` to the length bound, the fractional and the exclusive bounds are
+// rounded to the closest allowed length (e.g. the exclusive minimum `2.5` is the minimum length `3`). It returns nil if the bound was absent.
+func lengthParam(params Params, name string) *int {
+	n, ok := numberOf(params[name])
+	if !ok || n == nil {
+		return nil
+	}
+	f, _ := n.Float64()
+	exclusive := params["exclusive_"+name] == true
+	var l float64
+	switch {
+	case name == "min" && exclusive:
+		l = math.Floor(f) + 1
+	case name == "min":
+		l = math.Ceil(f)
+	case exclusive:
+		l = math.Ceil(f) - 1
+	default:
+		l = math.Floor(f)
+	}
+	i := int(math.Max(l, 0))
+	return &i
+}
+
+// intParam returns the integer parameter as a pointer.
+func intParam(params Params, name string) *int {
+	if v, ok := params[name].(int); ok {
+		return &v
+	}
+	return nil
+}
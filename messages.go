@@ -0,0 +1,267 @@
+package tavern
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"golang.org/x/text/language"
+)
+
+// Catalog is a set of the message templates keyed by the code of the validators (e.g. `CodeLength`).
+// The parameters of the validator can be interpolated with the braces (e.g. `{min}`), the `{field}` and `{value}` are also available.
+type Catalog map[string]string
+
+// catalogEnglish is the built-in English catalog, it's also the fallback catalog when a message wasn't found.
+var catalogEnglish = Catalog{
+	CodeWrongType:           "must be a {expected} but got {actual}",
+	CodePanic:               "could not be validated",
+	CodeRequired:            "is required",
+	CodeLength:              "must be between {min} and {max} characters",
+	CodeMaxLength:           "must be at most {max} characters",
+	CodeMinLength:           "must be at least {min} characters",
+	CodeFixedLength:         "must be exactly {length} characters",
+	CodeRange:               "must be between {min} and {max}",
+	CodeMaxRange:            "must be at most {max}",
+	CodeMinRange:            "must be at least {min}",
+	CodeMaximum:             "must not exceed {max}",
+	CodeMinimum:             "must not be less than {min}",
+	CodeDatetime:            "must be a datetime in the format of {format}",
+	CodeEmail:               "must be a valid email address",
+	CodeRegExp:              "must match the pattern {pattern}",
+	CodePrefix:              "must start with {prefix}",
+	CodeSuffix:              "must end with {suffix}",
+	CodeAlpha:               "must contain only alphabets",
+	CodeAlphanumeric:        "must contain only alphabets and numbers",
+	CodeAlphaUnicode:        "must contain only letters",
+	CodeAlphanumericUnicode: "must contain only letters and numbers",
+	CodeNumeric:             "must be a number",
+	CodeRGB:                 "must be a valid RGB color",
+	CodeRGBA:                "must be a valid RGBA color",
+	CodeHSL:                 "must be a valid HSL color",
+	CodeHSLA:                "must be a valid HSLA color",
+	CodeJSON:                "must be a valid JSON",
+	CodeBase64:              "must be a valid Base64 string",
+	CodeBase64URL:           "must be a valid URL Base64 string",
+	CodeBitcoinAddress:      "must be a valid Bitcoin address",
+	CodeISBN10:              "must be a valid ISBN-10",
+	CodeISBN13:              "must be a valid ISBN-13",
+	CodeUUID:                "must be a valid UUID",
+	CodeUUID3:               "must be a valid UUID version 3",
+	CodeUUID4:               "must be a valid UUID version 4",
+	CodeUUID5:               "must be a valid UUID version 5",
+	CodeASCII:               "must contain only ASCII characters",
+	CodeASCIIPrintable:      "must contain only printable ASCII characters",
+	CodeMultiByte:           "must contain multi-byte characters",
+	CodeDataURI:             "must be a valid data URI",
+	CodeLatitude:            "must be a valid latitude",
+	CodeLongitude:           "must be a valid longitude",
+	CodeTCPAddress:          "must be a resolvable TCP address",
+	CodeTCPv4Address:        "must be a resolvable TCPv4 address",
+	CodeTCPv6Address:        "must be a resolvable TCPv6 address",
+	CodeUDPAddress:          "must be a resolvable UDP address",
+	CodeUDPv4Address:        "must be a resolvable UDPv4 address",
+	CodeUDPv6Address:        "must be a resolvable UDPv6 address",
+	CodeIPAddress:           "must be a resolvable IP address",
+	CodeIPv4Address:         "must be a resolvable IPv4 address",
+	CodeIPv6Address:         "must be a resolvable IPv6 address",
+	CodeUnixAddress:         "must be a resolvable Unix address",
+	CodeHTML:                "must contain HTML",
+	CodeURL:                 "must be a valid URL",
+	CodeEqual:               "must be {expected}",
+	CodeOneOf:               "must be one of {values}",
+	CodeNotOneOf:            "must not be one of {values}",
+	CodeAnyOf:               "must satisfy any of the conditions",
+	CodeExactlyOneOf:        "must satisfy exactly one of the conditions",
+	CodeNot:                 "is not allowed",
+	CodeInt:                 "must be an integer",
+	CodeFloat:               "must be a number",
+	CodeBool:                "must be a boolean",
+	CodeTime:                "must be a time in the format of {layout}",
+	CodeDuration:            "must be a duration",
+	CodeInteger:             "must be an integer",
+	CodeUnknownField:        "is not allowed",
+	CodeEqualTo:             "must be equal to {other}",
+	CodeGreaterThanField:    "must be greater than {other}",
+	CodeLessThanField:       "must be less than {other}",
+	CodeRequiredIf:          "is required when {other} is {other_value}",
+	CodeRequiredUnless:      "is required unless {other} is {other_value}",
+	CodeRequiredWith:        "is required when {others} is present",
+	CodeExcludedWith:        "must be empty when {others} is present",
+}
+
+// catalogTraditionalChinese is the built-in Traditional Chinese (zh-TW) catalog.
+var catalogTraditionalChinese = Catalog{
+	CodeWrongType:           "型態不正確",
+	CodePanic:               "無法被驗證",
+	CodeRequired:            "為必填",
+	CodeLength:              "長度必須介於 {min} 到 {max} 個字元之間",
+	CodeMaxLength:           "長度不能超過 {max} 個字元",
+	CodeMinLength:           "長度至少要 {min} 個字元",
+	CodeFixedLength:         "長度必須為 {length} 個字元",
+	CodeRange:               "必須介於 {min} 到 {max} 之間",
+	CodeMaxRange:            "不能大於 {max}",
+	CodeMinRange:            "不能小於 {min}",
+	CodeMaximum:             "不能超過 {max}",
+	CodeMinimum:             "不能少於 {min}",
+	CodeDatetime:            "必須是 {format} 格式的日期時間",
+	CodeEmail:               "必須是有效的電子郵件地址",
+	CodeRegExp:              "必須符合 {pattern} 格式",
+	CodePrefix:              "必須以 {prefix} 開頭",
+	CodeSuffix:              "必須以 {suffix} 結尾",
+	CodeAlpha:               "只能包含英文字母",
+	CodeAlphanumeric:        "只能包含英文字母與數字",
+	CodeAlphaUnicode:        "只能包含文字",
+	CodeAlphanumericUnicode: "只能包含文字與數字",
+	CodeNumeric:             "必須是數字",
+	CodeRGB:                 "必須是有效的 RGB 色彩",
+	CodeRGBA:                "必須是有效的 RGBA 色彩",
+	CodeHSL:                 "必須是有效的 HSL 色彩",
+	CodeHSLA:                "必須是有效的 HSLA 色彩",
+	CodeJSON:                "必須是有效的 JSON",
+	CodeBase64:              "必須是有效的 Base64 字串",
+	CodeBase64URL:           "必須是有效的 URL Base64 字串",
+	CodeBitcoinAddress:      "必須是有效的比特幣地址",
+	CodeISBN10:              "必須是有效的 ISBN-10",
+	CodeISBN13:              "必須是有效的 ISBN-13",
+	CodeUUID:                "必須是有效的 UUID",
+	CodeUUID3:               "必須是有效的第 3 版 UUID",
+	CodeUUID4:               "必須是有效的第 4 版 UUID",
+	CodeUUID5:               "必須是有效的第 5 版 UUID",
+	CodeASCII:               "只能包含 ASCII 字元",
+	CodeASCIIPrintable:      "只能包含可列印的 ASCII 字元",
+	CodeMultiByte:           "必須包含多位元組字元",
+	CodeDataURI:             "必須是有效的 Data URI",
+	CodeLatitude:            "必須是有效的緯度",
+	CodeLongitude:           "必須是有效的經度",
+	CodeTCPAddress:          "必須是可解析的 TCP 位址",
+	CodeTCPv4Address:        "必須是可解析的 TCPv4 位址",
+	CodeTCPv6Address:        "必須是可解析的 TCPv6 位址",
+	CodeUDPAddress:          "必須是可解析的 UDP 位址",
+	CodeUDPv4Address:        "必須是可解析的 UDPv4 位址",
+	CodeUDPv6Address:        "必須是可解析的 UDPv6 位址",
+	CodeIPAddress:           "必須是可解析的 IP 位址",
+	CodeIPv4Address:         "必須是可解析的 IPv4 位址",
+	CodeIPv6Address:         "必須是可解析的 IPv6 位址",
+	CodeUnixAddress:         "必須是可解析的 Unix 位址",
+	CodeHTML:                "必須包含 HTML",
+	CodeURL:                 "必須是有效的網址",
+	CodeEqual:               "必須是 {expected}",
+	CodeOneOf:               "必須是 {values} 其中之一",
+	CodeNotOneOf:            "不能是 {values} 其中之一",
+	CodeAnyOf:               "必須符合其中一個條件",
+	CodeExactlyOneOf:        "必須剛好符合其中一個條件",
+	CodeNot:                 "不被允許",
+	CodeInt:                 "必須是整數",
+	CodeFloat:               "必須是數字",
+	CodeBool:                "必須是布林值",
+	CodeTime:                "必須是 {layout} 格式的時間",
+	CodeDuration:            "必須是時間長度",
+	CodeInteger:             "必須是整數",
+	CodeUnknownField:        "是不允許的欄位",
+	CodeEqualTo:             "必須與 {other} 相同",
+	CodeGreaterThanField:    "必須大於 {other}",
+	CodeLessThanField:       "必須小於 {other}",
+	CodeRequiredIf:          "當 {other} 為 {other_value} 時為必填",
+	CodeRequiredUnless:      "除非 {other} 為 {other_value}，否則為必填",
+	CodeRequiredWith:        "當 {others} 存在時為必填",
+	CodeExcludedWith:        "當 {others} 存在時必須為空",
+}
+
+var (
+	// catalogMu guards the catalogs and the matcher.
+	catalogMu sync.RWMutex
+	// catalogTags are the locales of the registered catalogs, the first one is the fallback.
+	catalogTags = []language.Tag{language.English, language.MustParse("zh-TW")}
+	// catalogs are the registered catalogs in the same order of `catalogTags`.
+	catalogs = []Catalog{catalogEnglish, catalogTraditionalChinese}
+	// catalogMatcher matches the requested locale to the registered catalogs.
+	catalogMatcher = language.NewMatcher(catalogTags)
+)
+
+// regExpPlaceholder matches the `{name}` placeholders in the message templates.
+var regExpPlaceholder = regexp.MustCompile(`\{(\w+)\}`)
+
+// RegisterCatalog registers the catalog for the locale, the messages are merged into the existing catalog if the locale was already registered.
+func RegisterCatalog(tag language.Tag, c Catalog) {
+	catalogMu.Lock()
+	defer catalogMu.Unlock()
+
+	for i, v := range catalogTags {
+		if v == tag {
+			catalogs[i] = mergeCatalogs(catalogs[i], c)
+			return
+		}
+	}
+	catalogTags = append(catalogTags, tag)
+	catalogs = append(catalogs, mergeCatalogs(c))
+	catalogMatcher = language.NewMatcher(catalogTags)
+}
+
+// mergeCatalogs copies the catalogs into a new catalog, the latter overrides the former.
+func mergeCatalogs(cs ...Catalog) Catalog {
+	merged := make(Catalog)
+	for _, c := range cs {
+		for code, msg := range c {
+			merged[code] = msg
+		}
+	}
+	return merged
+}
+
+// Localize renders the validation error in the language that best matches the tag, the English message is used if the locale doesn't have the message.
+// The errors in `Errors` are rendered one by one, prefixed with their fields (e.g. `email: must be a valid email address`) and joined with a semicolon.
+// A single `ValidationError` is rendered without the field, and an error that isn't a `ValidationError` returns it's original message.
+func Localize(err error, tag language.Tag) string {
+	var errs Errors
+	if errors.As(err, &errs) {
+		msgs := make([]string, len(errs))
+		for i, v := range errs {
+			msgs[i] = Localize(v, tag)
+			var verr *ValidationError
+			if errors.As(v, &verr) && verr.Field != "" {
+				msgs[i] = verr.Field + ": " + msgs[i]
+			}
+		}
+		return strings.Join(msgs, "; ")
+	}
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		return err.Error()
+	}
+
+	catalogMu.RLock()
+	_, i, _ := catalogMatcher.Match(tag)
+	tmpl, ok := catalogs[i][verr.Code]
+	if !ok {
+		tmpl, ok = catalogs[0][verr.Code]
+	}
+	catalogMu.RUnlock()
+	if !ok {
+		return verr.Error()
+	}
+	return regExpPlaceholder.ReplaceAllStringFunc(tmpl, func(s string) string {
+		name := s[1 : len(s)-1]
+		switch name {
+		case "field":
+			return verr.Field
+		case "value":
+			return fmt.Sprint(verr.Value)
+		}
+		if v, ok := verr.Params[name]; ok {
+			return fmt.Sprint(v)
+		}
+		return s
+	})
+}
+
+// LocalizeString is the same as `Localize` but accepts a locale string (e.g. `zh-TW`, `en-US`), it falls back to English if the locale was malformed.
+func LocalizeString(err error, locale string) string {
+	tag, parseErr := language.Parse(locale)
+	if parseErr != nil {
+		tag = language.English
+	}
+	return Localize(err, tag)
+}
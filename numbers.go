@@ -0,0 +1,137 @@
+package tavern
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"math/big"
+	"reflect"
+)
+
+// Numeric is a constraint that permits any integer and floating-point type.
+type Numeric interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr | ~float32 | ~float64
+}
+
+// Bound configures the inclusiveness of the bounds of a range, the bounds are inclusive by default.
+type Bound int
+
+const (
+	// Inclusive includes both of the bounds.
+	Inclusive Bound = 0
+	// ExclusiveMin excludes the minimum bound.
+	ExclusiveMin Bound = 1
+	// ExclusiveMax excludes the maximum bound.
+	ExclusiveMax Bound = 2
+	// Exclusive excludes both of the bounds.
+	Exclusive = ExclusiveMin | ExclusiveMax
+)
+
+// limit is a bound of the range.
+type limit struct {
+	// n is the number of the bound, it's nil if the bound was NaN.
+	n *big.Float
+	// exclusive excludes the bound itself.
+	exclusive bool
+}
+
+// newLimit creates a limit with the number.
+func newLimit(v interface{}, exclusive bool) *limit {
+	n, _ := numberOf(v)
+	return &limit{n: n, exclusive: exclusive}
+}
+
+// numberOf converts the number to a `big.Float` without losing the precision, the returned number is nil if the value was NaN.
+// The `json.Number` is parsed as a number, it returns false if the value wasn't a number.
+func numberOf(v interface{}) (*big.Float, bool) {
+	if n, ok := v.(json.Number); ok {
+		f, _, err := big.ParseFloat(string(n), 10, 256, big.ToNearestEven)
+		if err != nil {
+			return nil, false
+		}
+		return f, true
+	}
+	value := reflect.ValueOf(v)
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Float).SetInt64(value.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Float).SetUint64(value.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		if math.IsNaN(value.Float()) {
+			return nil, true
+		}
+		return new(big.Float).SetFloat64(value.Float()), true
+	default:
+		return nil, false
+	}
+}
+
+// within reports whether the number is within the limits, a nil limit is unbounded. NaN is never within any limits.
+func within(n *big.Float, min, max *limit) bool {
+	if n == nil {
+		return false
+	}
+	if min != nil {
+		if min.n == nil {
+			return false
+		}
+		if c := n.Cmp(min.n); c < 0 || (c == 0 && min.exclusive) {
+			return false
+		}
+	}
+	if max != nil {
+		if max.n == nil {
+			return false
+		}
+		if c := n.Cmp(max.n); c > 0 || (c == 0 && max.exclusive) {
+			return false
+		}
+	}
+	return true
+}
+
+// boundOf combines the bound options.
+func boundOf(bounds []Bound) (b Bound) {
+	for _, v := range bounds {
+		b |= v
+	}
+	return b
+}
+
+// rangeParams creates the parameters of a range validator, the exclusiveness is only included when the bound was exclusive.
+func rangeParams(min, max interface{}, b Bound) Params {
+	params := Params{}
+	if min != nil {
+		params["min"] = min
+		if b&ExclusiveMin != 0 {
+			params["exclusive_min"] = true
+		}
+	}
+	if max != nil {
+		params["max"] = max
+		if b&ExclusiveMax != 0 {
+			params["exclusive_max"] = true
+		}
+	}
+	return params
+}
+
+// rangeValidator creates a validator that requires the number of the value to be within the limits.
+func rangeValidator(code string, min, max *limit, params Params) Validator {
+	return describe(description{code: code, params: params, err: checkRange(min, max)}, func(ctx context.Context, v interface{}) (context.Context, error) {
+		if isNotRequiredAndZeroValue(ctx, v) {
+			return ctx, nil
+		}
+		v = indirect(v)
+
+		n, ok := numberOf(v)
+		if !ok {
+			return ctx, wrongType(kindNumber, v)
+		}
+		if !within(n, min, max) {
+			return ctx, newError(code, ErrRange, v, params)
+		}
+		return ctx, nil
+	})
+}
@@ -0,0 +1,161 @@
+// Package openapi generates the OpenAPI 3.1 components from the tavern rules and schemas, so the documented constraints
+// (e.g. `required`, `minLength`, `pattern`, `enum`) are derived from the same validators that validate the requests.
+package openapi
+
+import (
+	"sort"
+
+	"github.com/teacat/tavern"
+)
+
+// The locations of the parameters.
+const (
+	// InQuery is the parameter in the query string (e.g. `/users?page=2`).
+	InQuery = "query"
+	// InPath is the parameter in the path (e.g. `/users/{id}`), it's always required.
+	InPath = "path"
+	// InHeader is the parameter in the request headers.
+	InHeader = "header"
+	// InCookie is the parameter in the cookies.
+	InCookie = "cookie"
+)
+
+// MediaTypeJSON is the media type of the request bodies.
+const MediaTypeJSON = "application/json"
+
+// Parameter is an OpenAPI parameter object.
+type Parameter struct {
+	// Name of the parameter, it's the name of the rule.
+	Name string `json:"name"`
+	// In is the location of the parameter (e.g. `InQuery`).
+	In string `json:"in"`
+	// Required reports whether the parameter is required.
+	Required bool `json:"required,omitempty"`
+	// Schema is the JSON Schema of the parameter.
+	Schema map[string]interface{} `json:"schema"`
+}
+
+// MediaType is an OpenAPI media type object.
+type MediaType struct {
+	// Schema is the JSON Schema of the content.
+	Schema map[string]interface{} `json:"schema"`
+}
+
+// RequestBody is an OpenAPI request body object.
+type RequestBody struct {
+	// Required reports whether the request body is required.
+	Required bool `json:"required,omitempty"`
+	// Content maps the media types to the schemas.
+	Content map[string]MediaType `json:"content"`
+}
+
+// Components is an OpenAPI components object, it can be embedded in the document as the `components` field.
+type Components struct {
+	// Schemas are the reusable schemas.
+	Schemas map[string]map[string]interface{} `json:"schemas,omitempty"`
+	// Parameters are the reusable parameters.
+	Parameters map[string]Parameter `json:"parameters,omitempty"`
+	// RequestBodies are the reusable request bodies.
+	RequestBodies map[string]RequestBody `json:"requestBodies,omitempty"`
+}
+
+// NewComponents creates an empty components object.
+func NewComponents() *Components {
+	return &Components{
+		Schemas:       make(map[string]map[string]interface{}),
+		Parameters:    make(map[string]Parameter),
+		RequestBodies: make(map[string]RequestBody),
+	}
+}
+
+// AddSchema adds the schema of the named rules to the components, it returns the reference of the schema (e.g. `#/components/schemas/User`).
+func (c *Components) AddSchema(name string, rules ...tavern.Rule) string {
+	c.Schemas[name] = Schema(rules...)
+	return "#/components/schemas/" + name
+}
+
+// AddSchemaOf adds the schema of the tavern schema to the components, it returns the reference of the schema.
+func (c *Components) AddSchemaOf(name string, s *tavern.Schema) string {
+	c.Schemas[name] = SchemaOf(s)
+	return "#/components/schemas/" + name
+}
+
+// AddRequestBody adds a required JSON request body that references the schema to the components, it returns the reference of the request body.
+func (c *Components) AddRequestBody(name, schemaRef string) string {
+	c.RequestBodies[name] = JSONRequestBody(map[string]interface{}{"$ref": schemaRef}, true)
+	return "#/components/requestBodies/" + name
+}
+
+// AddParameters adds the parameters to the components by their names, it returns the references of the parameters in the same order.
+func (c *Components) AddParameters(params ...Parameter) []string {
+	refs := make([]string, len(params))
+	for i, p := range params {
+		c.Parameters[p.Name] = p
+		refs[i] = "#/components/parameters/" + p.Name
+	}
+	return refs
+}
+
+// Schema converts the named rules to the schema of an object, see `tavern.JSONSchema`.
+// OpenAPI 3.1 uses the JSON Schema Draft 2020-12 by default, so the `$schema` is omitted.
+func Schema(rules ...tavern.Rule) map[string]interface{} {
+	return withoutDialect(tavern.JSONSchema(rules...))
+}
+
+// SchemaOf converts the tavern schema to the schema of an object, see `tavern.Schema.JSONSchema`.
+func SchemaOf(s *tavern.Schema) map[string]interface{} {
+	return withoutDialect(s.JSONSchema())
+}
+
+// Parameters converts the named rules to the parameters in the location (e.g. `InQuery`), the parameters are sorted by their names.
+// A parameter is required if the rule has `WithRequired`, the path parameters are always required.
+func Parameters(in string, rules ...tavern.Rule) []Parameter {
+	return parameters(in, Schema(rules...))
+}
+
+// ParametersOf converts the fields of the tavern schema to the parameters in the location, see `Parameters`.
+func ParametersOf(in string, s *tavern.Schema) []Parameter {
+	return parameters(in, SchemaOf(s))
+}
+
+// JSONRequestBody creates a request body with the JSON content of the schema.
+func JSONRequestBody(schema map[string]interface{}, required bool) RequestBody {
+	return RequestBody{
+		Required: required,
+		Content:  map[string]MediaType{MediaTypeJSON: {Schema: schema}},
+	}
+}
+
+// withoutDialect removes the `$schema` of the exported JSON Schema document.
+func withoutDialect(doc map[string]interface{}) map[string]interface{} {
+	delete(doc, "$schema")
+	return doc
+}
+
+// parameters converts the properties of the object schema to the parameters.
+func parameters(in string, schema map[string]interface{}) []Parameter {
+	props, _ := schema["properties"].(map[string]interface{})
+	required := make(map[string]bool)
+	if names, ok := schema["required"].([]string); ok {
+		for _, v := range names {
+			required[v] = true
+		}
+	}
+
+	names := make([]string, 0, len(props))
+	for name := range props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	params := make([]Parameter, len(names))
+	for i, name := range names {
+		params[i] = Parameter{
+			Name:     name,
+			In:       in,
+			Required: in == InPath || required[name],
+			Schema:   props[name].(map[string]interface{}),
+		}
+	}
+	return params
+}
@@ -0,0 +1,73 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/teacat/tavern"
+)
+
+func TestSchema(t *testing.T) {
+	a := assert.New(t)
+	schema := Schema(
+		tavern.NewNamedRule("username", "", tavern.WithRequired(), tavern.WithLength(3, 20), tavern.WithRegExp(`^[a-z0-9_]+$`)),
+		tavern.NewNamedRule("email", "", tavern.WithEmail()),
+		tavern.NewNamedRule("role", "", tavern.WithOneOf("admin", "user")),
+		tavern.NewNamedRule("age", 0, tavern.WithRange(18, 130)),
+	)
+	a.NotContains(schema, "$schema")
+	a.Equal([]string{"username"}, schema["required"])
+	props := schema["properties"].(map[string]interface{})
+	a.Equal(map[string]interface{}{"type": "string", "minLength": 3, "maxLength": 20, "pattern": `^[a-z0-9_]+$`}, props["username"])
+	a.Equal(map[string]interface{}{"type": "string", "format": "email"}, props["email"])
+	a.Equal(map[string]interface{}{"type": "string", "enum": []interface{}{"admin", "user"}}, props["role"])
+	a.Equal(map[string]interface{}{"type": "integer", "minimum": 18, "maximum": 130}, props["age"])
+}
+
+func TestParameters(t *testing.T) {
+	a := assert.New(t)
+	params := Parameters(InQuery,
+		tavern.NewNamedRule("sort", "", tavern.WithOneOf("asc", "desc")),
+		tavern.NewNamedRule("page", "", tavern.WithRequired(), tavern.WithInt(), tavern.WithMinRange(1)),
+	)
+	a.Equal([]Parameter{
+		{Name: "page", In: InQuery, Required: true, Schema: map[string]interface{}{"type": "integer", "minimum": 1}},
+		{Name: "sort", In: InQuery, Schema: map[string]interface{}{"type": "string", "enum": []interface{}{"asc", "desc"}}},
+	}, params)
+
+	params = ParametersOf(InPath, tavern.MustNewSchema(tavern.Field("id", tavern.WithUUID())))
+	a.Equal([]Parameter{
+		{Name: "id", In: InPath, Required: true, Schema: map[string]interface{}{"type": "string", "format": "uuid"}},
+	}, params)
+}
+
+func TestComponents(t *testing.T) {
+	a := assert.New(t)
+	c := NewComponents()
+	ref := c.AddSchemaOf("Signup", tavern.MustNewSchema(
+		tavern.Field("username", tavern.WithMaxLength(20)).Required(),
+	).Strict())
+	a.Equal("#/components/schemas/Signup", ref)
+	a.Equal("#/components/requestBodies/Signup", c.AddRequestBody("Signup", ref))
+	a.Equal([]string{"#/components/parameters/page"}, c.AddParameters(Parameters(InQuery, tavern.NewNamedRule("page", "", tavern.WithInt()))...))
+
+	b, err := json.Marshal(c)
+	a.NoError(err)
+	a.JSONEq(`{
+		"schemas": {
+			"Signup": {
+				"type": "object",
+				"properties": {"username": {"maxLength": 20}},
+				"required": ["username"],
+				"additionalProperties": false
+			}
+		},
+		"parameters": {
+			"page": {"name": "page", "in": "query", "schema": {"type": "integer"}}
+		},
+		"requestBodies": {
+			"Signup": {"required": true, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Signup"}}}}
+		}
+	}`, string(b))
+}
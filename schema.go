@@ -0,0 +1,221 @@
+package tavern
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// Schema is a compiled set of the field rules, it's declared once (e.g. at the startup) and reused for every validation.
+// The configuration of the validators is checked when it was compiled, and it's safe for concurrent use.
+type Schema struct {
+	fields []*FieldRule
+	// names are the names of the fields.
+	names map[string]bool
+	// strict rejects the keys of the map that were not declared.
+	strict bool
+}
+
+// FieldRule declares the validators of a field in a `Schema`, the value of the field is read with an accessor.
+type FieldRule struct {
+	// name of the field, it's the key of the map or reported as the `Field` of the `ValidationError`.
+	name string
+	// get reads the value of the field and reports whether the field was found, it returns false as the last value if the validated value had a wrong type.
+	get func(v interface{}) (interface{}, bool, bool)
+	// expected is the description of the type that the accessor accepts.
+	expected string
+	// validators to validate the value of the field.
+	validators []Validator
+	// exhaustive runs all the validators of the field even if one of them was failed.
+	exhaustive bool
+	// required requires the key to be present in the map.
+	required bool
+}
+
+var (
+	// missingField are the validators of a required field that was not present.
+	missingField = []Validator{func(ctx context.Context, v interface{}) (context.Context, error) {
+		return ctx, newError(CodeRequired, ErrRequired, nil, nil)
+	}}
+	// unknownField are the validators of a key that was not declared in a strict schema.
+	unknownField = []Validator{func(ctx context.Context, v interface{}) (context.Context, error) {
+		return ctx, newError(CodeUnknownField, ErrUnknownField, indirect(v), nil)
+	}}
+)
+
+// Field declares the validators of the value in a map with the string keys (e.g. `map[string]interface{}`), a missing key is an absent value.
+func Field(name string, validators ...Validator) *FieldRule {
+	return &FieldRule{
+		name:       name,
+		get:        mapAccessor(name),
+		expected:   kindMap,
+		validators: validators,
+	}
+}
+
+// FieldFunc declares the validators of the value that read by the accessor, it's used to validate a struct (e.g. `func(u *User) interface{} { return u.Email }`).
+func FieldFunc[T any](name string, get func(T) interface{}, validators ...Validator) *FieldRule {
+	return &FieldRule{
+		name: name,
+		get: func(v interface{}) (interface{}, bool, bool) {
+			t, ok := v.(T)
+			if !ok {
+				return nil, false, false
+			}
+			return get(t), true, true
+		},
+		expected:   fmt.Sprintf("%T", *new(T)),
+		validators: validators,
+	}
+}
+
+// Exhaustive keeps running the rest of the validators of the field after one was failed, see `Rule.Exhaustive`.
+func (f *FieldRule) Exhaustive() *FieldRule {
+	f.exhaustive = true
+	return f
+}
+
+// Required requires the key to be present in the map, unlike `WithRequired` the zero values (e.g. `0`, `false`, `null`) are allowed.
+// The fields are optional by default, a missing key is validated as an absent value.
+func (f *FieldRule) Required() *FieldRule {
+	f.required = true
+	return f
+}
+
+// mapAccessor creates an accessor that reads the key of the map.
+func mapAccessor(key string) func(v interface{}) (interface{}, bool, bool) {
+	return func(v interface{}) (interface{}, bool, bool) {
+		switch m := v.(type) {
+		case map[string]interface{}:
+			e, found := m[key]
+			return e, found, true
+		case map[string]string:
+			if s, found := m[key]; found {
+				return s, true, true
+			}
+			return nil, false, true
+		}
+		value := reflect.ValueOf(indirect(v))
+		if value.Kind() != reflect.Map || value.Type().Key().Kind() != reflect.String {
+			return nil, false, false
+		}
+		e := value.MapIndex(reflect.ValueOf(key).Convert(value.Type().Key()))
+		if !e.IsValid() {
+			return nil, false, true
+		}
+		return e.Interface(), true, true
+	}
+}
+
+// NewSchema compiles the fields to a schema, it returns an error that wraps `ErrInvalidConfig` if a field was unnamed or duplicated,
+// or a validator of the field was misconfigured (e.g. `WithLength(5, 1)`, `WithRegExp("[")`).
+func NewSchema(fields ...*FieldRule) (*Schema, error) {
+	names := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		if f.name == "" {
+			return nil, invalidConfig("the field is unnamed")
+		}
+		if names[f.name] {
+			return nil, invalidConfig("the field %q is duplicated", f.name)
+		}
+		names[f.name] = true
+
+		if err := checkValidators(f.validators); err != nil {
+			return nil, configError(f.name, err)
+		}
+	}
+	return &Schema{fields: fields, names: names}, nil
+}
+
+// Strict returns a copy of the schema which rejects the keys of the map that were not declared, the unknown keys are reported with `CodeUnknownField`.
+// It has no effect on the fields that declared by `FieldFunc`.
+func (s *Schema) Strict() *Schema {
+	cp := *s
+	cp.strict = true
+	return &cp
+}
+
+// MustNewSchema is the same as `NewSchema` but panics if the schema was invalid, it's useful to declare the schema as a package variable.
+func MustNewSchema(fields ...*FieldRule) *Schema {
+	s, err := NewSchema(fields...)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Validate validates the value with the schema, it stops and returns the error once a validator was failed.
+func (s *Schema) Validate(v interface{}) error {
+	return s.ValidateContext(context.Background(), v)
+}
+
+// ValidateContext is the same as `Validate` but the context of each field is derived from the passed-in context.
+func (s *Schema) ValidateContext(ctx context.Context, v interface{}) error {
+	rules, err := s.rules(v)
+	if err != nil {
+		return err
+	}
+	return ValidateContext(ctx, rules...)
+}
+
+// ValidateAll validates the value with the schema and collects every failure into `Errors`.
+func (s *Schema) ValidateAll(v interface{}) error {
+	return s.ValidateAllContext(context.Background(), v)
+}
+
+// ValidateAllContext is the same as `ValidateAll` but the context of each field is derived from the passed-in context.
+func (s *Schema) ValidateAllContext(ctx context.Context, v interface{}) error {
+	rules, err := s.rules(v)
+	if err != nil {
+		return err
+	}
+	return ValidateAllContext(ctx, rules...)
+}
+
+// rules reads the values of the fields and creates the named rules, the fields share the validators with the schema.
+// The missing required keys and the unknown keys of a strict schema are validated by the validators that always fail.
+func (s *Schema) rules(v interface{}) ([]Rule, error) {
+	rules := make([]Rule, len(s.fields))
+	for i, f := range s.fields {
+		value, found, ok := f.get(v)
+		if !ok {
+			return nil, wrongType(f.expected, v)
+		}
+		validators := f.validators
+		if f.required && !found {
+			validators = missingField
+		}
+		rules[i] = Rule{name: f.name, value: value, validators: validators, exhaustive: f.exhaustive}
+	}
+	if !s.strict {
+		return rules, nil
+	}
+	value := reflect.ValueOf(indirect(v))
+	if value.Kind() != reflect.Map || value.Type().Key().Kind() != reflect.String {
+		return rules, nil
+	}
+	for _, key := range sortedKeys(value) {
+		if name := key.String(); !s.names[name] {
+			rules = append(rules, Rule{name: name, value: value.MapIndex(key).Interface(), validators: unknownField})
+		}
+	}
+	return rules, nil
+}
+
+// WithObject requires the value (e.g. a nested object of a decoded JSON) to pass the schema, the failures are collected into `Errors`
+// and the path of the nested field is reported in the `Field` of the `ValidationError` (e.g. `address.zip`, `items[2].sku`).
+func WithObject(schema *Schema) Validator {
+	var err error
+	if schema == nil {
+		err = invalidConfig("the schema is nil")
+	}
+	return describe(description{code: codeObject, err: err, schema: schema}, func(ctx context.Context, v interface{}) (context.Context, error) {
+		if isNotRequiredAndZeroValue(ctx, v) {
+			return ctx, nil
+		}
+		if verr := schema.ValidateAllContext(elementContext(ctx), indirect(v)); verr != nil {
+			return ctx, verr
+		}
+		return ctx, nil
+	})
+}
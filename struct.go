@@ -0,0 +1,166 @@
+package tavern
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Validatable is implemented by the types that describe their own rules, the rules should be named so the failures can be located.
+type Validatable interface {
+	// Rules returns the rules of the value.
+	Rules() []Rule
+}
+
+// ValidateStruct validates the rules of the value, the value of a rule that also implements `Validatable` is validated recursively,
+// so are the elements of the slices, arrays and maps. Every failure is collected into `Errors`, and the `Field` of the `ValidationError`
+// is the dotted path of the value (e.g. `address.zip`, `items[2].sku`). A cyclic reference (e.g. a back-reference to the parent) is not validated again.
+func ValidateStruct(v Validatable) error {
+	return ValidateStructContext(context.Background(), v)
+}
+
+// ValidateStructContext is the same as `ValidateStruct` but the context of each rule is derived from the passed-in context.
+// It stops and returns `ctx.Err()` as is once the context was cancelled.
+func ValidateStructContext(ctx context.Context, v Validatable) error {
+	visited := make(map[reference]bool)
+	if ref, ok := referenceOf(v); ok {
+		visited[ref] = true
+	}
+	errs, err := validateStruct(ctx, "", v, visited)
+	if err != nil {
+		return err
+	}
+	if len(errs) != 0 {
+		return errs
+	}
+	return nil
+}
+
+// reference identifies a pointer, a map or a slice by it's address, so the cyclic references can be detected.
+type reference struct {
+	ptr uintptr
+	len int
+	typ reflect.Type
+}
+
+// referenceOf returns the reference of the value, it returns false if the value wasn't a non-nil pointer, map or slice.
+func referenceOf(v interface{}) (reference, bool) {
+	value := reflect.ValueOf(v)
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Map:
+		if !value.IsNil() {
+			return reference{ptr: value.Pointer(), typ: value.Type()}, true
+		}
+	case reflect.Slice:
+		if !value.IsNil() {
+			return reference{ptr: value.Pointer(), len: value.Len(), typ: value.Type()}, true
+		}
+	}
+	return reference{}, false
+}
+
+// validateStruct validates the rules of the value and the nested values with the path prefix,
+// the visited are the references of the ancestors so a cyclic reference is skipped.
+func validateStruct(ctx context.Context, prefix string, v Validatable, visited map[reference]bool) (Errors, error) {
+	var errs Errors
+	rules := v.Rules()
+	ctx = withValues(ctx, rules)
+	for _, r := range rules {
+		rerrs, err := r.validate(ctx, r.exhaustive)
+		if err != nil {
+			return nil, err
+		}
+		errs = append(errs, nestErrors(prefix, nil, rerrs)...)
+
+		nerrs, err := validateNested(ctx, joinPath(prefix, r.name), r.value, visited)
+		if err != nil {
+			return nil, err
+		}
+		errs = append(errs, nerrs...)
+	}
+	return errs, nil
+}
+
+// validateNested validates the value if it implements `Validatable`, or the elements of it if it was a slice, an array or a map.
+// The value is skipped if it was referenced by an ancestor.
+func validateNested(ctx context.Context, path string, v interface{}, visited map[reference]bool) (Errors, error) {
+	if ref, ok := referenceOf(v); ok {
+		if visited[ref] {
+			return nil, nil
+		}
+		visited[ref] = true
+		defer delete(visited, ref)
+	}
+	v = indirect(v)
+	if v == nil {
+		return nil, nil
+	}
+	if nested, ok := validatableOf(v); ok {
+		return validateStruct(ctx, path, nested, visited)
+	}
+
+	var errs Errors
+	value := reflect.ValueOf(v)
+	if !mayNest(value.Type()) {
+		return nil, nil
+	}
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			eerrs, err := validateNested(ctx, fmt.Sprintf("%s[%d]", path, i), value.Index(i).Interface(), visited)
+			if err != nil {
+				return nil, err
+			}
+			errs = append(errs, eerrs...)
+		}
+	case reflect.Map:
+		for _, key := range sortedKeys(value) {
+			eerrs, err := validateNested(ctx, fmt.Sprintf("%s[%v]", path, key.Interface()), value.MapIndex(key).Interface(), visited)
+			if err != nil {
+				return nil, err
+			}
+			errs = append(errs, eerrs...)
+		}
+	}
+	return errs, nil
+}
+
+// mayNest reports whether the elements of the collection type may implement `Validatable`, so the slices of the basic types (e.g. `[]byte`) are not iterated.
+func mayNest(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		switch t.Elem().Kind() {
+		case reflect.Struct, reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Array, reflect.Map:
+			return true
+		}
+		return reflect.PtrTo(t.Elem()).NumMethod() != 0
+	}
+	return false
+}
+
+// validatableOf returns the value as a `Validatable`, the pointer of the value is also checked in case the `Rules` method has a pointer receiver.
+func validatableOf(v interface{}) (Validatable, bool) {
+	if nested, ok := v.(Validatable); ok {
+		return nested, true
+	}
+	value := reflect.ValueOf(v)
+	ptr := reflect.New(value.Type())
+	ptr.Elem().Set(value)
+	nested, ok := ptr.Interface().(Validatable)
+	return nested, ok
+}
+
+// joinPath joins the path prefix and the field name with a dot, the indexes (e.g. `[2]`) are joined without the dot.
+func joinPath(prefix, field string) string {
+	switch {
+	case prefix == "":
+		return field
+	case field == "":
+		return prefix
+	case strings.HasPrefix(field, "["):
+		return prefix + field
+	default:
+		return prefix + "." + field
+	}
+}
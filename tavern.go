@@ -1,6 +1,9 @@
 package tavern
 
-import "context"
+import (
+	"context"
+	"errors"
+)
 
 // Rule has the validators to validate the value. The name is optional, a named rule is useful if you wanted to know which value is invalid.
 type Rule struct {
@@ -10,21 +13,55 @@ type Rule struct {
 	value interface{}
 	// Validators to validate the value.
 	validators []Validator
+	// exhaustive runs all the validators of the rule even if one of them was failed.
+	exhaustive bool
 }
 
 // Validator is used to validate the value, it accepts a context to pass between the validators.
 type Validator func(ctx context.Context, value interface{}) (context.Context, error)
 
-// Validate validates all the rules that passed in.
-func Validate(rules ...Rule) (err error) {
+// Validate validates all the rules that passed in. It stops and returns the error once a validator was failed.
+func Validate(rules ...Rule) error {
+	return ValidateContext(context.Background(), rules...)
+}
+
+// ValidateContext is the same as `Validate` but the context of each rule is derived from the passed-in context,
+// so the validators are able to access the request-scoped values and respect the deadline.
+// It stops and returns `ctx.Err()` as is once the context was cancelled, so is the configuration error of a misconfigured validator (see `Rule.Check`).
+func ValidateContext(ctx context.Context, rules ...Rule) error {
+	ctx = withValues(ctx, rules)
 	for _, v := range rules {
-		ctx := context.Background()
-		for _, j := range v.validators {
-			ctx, err = j(ctx, v.value)
-			if err != nil {
-				return err
-			}
+		errs, err := v.validate(ctx, false)
+		if err != nil {
+			return err
+		}
+		if len(errs) != 0 {
+			return errs[0]
+		}
+	}
+	return nil
+}
+
+// ValidateAll validates all the rules that passed in and collects every failure into `Errors` instead of stopping at the first one.
+// A rule stops at it's first failed validator unless it was marked as `Exhaustive`.
+func ValidateAll(rules ...Rule) error {
+	return ValidateAllContext(context.Background(), rules...)
+}
+
+// ValidateAllContext is the same as `ValidateAll` but the context of each rule is derived from the passed-in context.
+// It stops and returns `ctx.Err()` or the configuration error as is, the collected failures are discarded.
+func ValidateAllContext(ctx context.Context, rules ...Rule) error {
+	var errs Errors
+	ctx = withValues(ctx, rules)
+	for _, v := range rules {
+		rerrs, err := v.validate(ctx, v.exhaustive)
+		if err != nil {
+			return err
 		}
+		errs = append(errs, rerrs...)
+	}
+	if len(errs) != 0 {
+		return errs
 	}
 	return nil
 }
@@ -36,3 +73,121 @@ func NewRule(value interface{}, validators ...Validator) Rule {
 		validators: validators,
 	}
 }
+
+// NewNamedRule creates a new rule like `NewRule` but with a name, the name will be reported as the `Field` of the `ValidationError` when the value is invalid.
+func NewNamedRule(name string, value interface{}, validators ...Validator) Rule {
+	return Rule{
+		name:       name,
+		value:      value,
+		validators: validators,
+	}
+}
+
+// Exhaustive returns a copy of the rule which keeps running the rest of it's validators after one was failed, so `ValidateAll` reports every failure of the rule.
+func (r Rule) Exhaustive() Rule {
+	r.exhaustive = true
+	return r
+}
+
+// Check reports the configuration error of the validators (e.g. `WithLength(5, 1)`, an invalid pattern of `WithRegExp`),
+// so the programming mistakes can be found before the validation. The returned error wraps `ErrInvalidConfig`.
+// The validation returns the same error as is instead of a `ValidationError` if the rule wasn't checked.
+func (r Rule) Check() error {
+	return configError(r.name, checkValidators(r.validators))
+}
+
+// clone returns a copy of the rule that doesn't share the validators with the original one.
+func (r Rule) clone() Rule {
+	r.validators = append([]Validator(nil), r.validators...)
+	return r
+}
+
+// withValues stores the values of the named rules into the context, so the cross-field validators are able to reference the other values.
+func withValues(ctx context.Context, rules []Rule) context.Context {
+	values := make(map[string]interface{}, len(rules))
+	for _, v := range rules {
+		if v.name != "" {
+			values[v.name] = v.value
+		}
+	}
+	return context.WithValue(ctx, KeyValues, values)
+}
+
+// validate runs the validators of the rule with the context that derived from the parent and converts the failures to `ValidationError`,
+// it stops at the first failure unless `all` is true. The error of the parent context is returned separately once it was cancelled.
+// The transformed value is passed to the later validators and written back into the value if it was a pointer, a nil context that returned by a validator is ignored.
+func (r Rule) validate(parent context.Context, all bool) (Errors, error) {
+	var errs Errors
+	ctx, value := parent, r.value
+	for _, j := range r.validators {
+		if err := parent.Err(); err != nil {
+			return nil, err
+		}
+		next, verr := call(ctx, j, value)
+		if next != nil {
+			ctx = next
+		}
+		if verr != nil {
+			if err := parent.Err(); err != nil {
+				return nil, err
+			}
+			if errors.Is(verr, ErrInvalidConfig) {
+				return nil, configError(r.name, verr)
+			}
+			errs = append(errs, nestErrors(r.name, value, verr)...)
+			if !all {
+				break
+			}
+			continue
+		}
+		value = valueOf(ctx, value)
+	}
+	if ctx.Value(KeyValue) != nil {
+		r.writeBack(parent, value)
+	}
+	return errs, nil
+}
+
+// writeBack writes the transformed value back into the pointer value of the rule,
+// and updates the value of the named rule in the context so the cross-field validators of the later rules see the transformed value.
+func (r Rule) writeBack(ctx context.Context, v interface{}) {
+	assign(r.value, v)
+	if values, ok := ctx.Value(KeyValues).(map[string]interface{}); ok && r.name != "" {
+		values[r.name] = v
+	}
+}
+
+// RecoverWrongType returns a context that makes the validation return a `ValidationError` with `CodeWrongType` instead of panicking,
+// regardless of `PanicOnWrongType`. It's useful for validating the untrusted input (e.g. the request bodies) that shouldn't crash the program.
+// Unlike disabling `PanicOnWrongType`, the other panics of the custom validators are propagated as is.
+func RecoverWrongType(ctx context.Context) context.Context {
+	return context.WithValue(ctx, keyRecoverWrongType, true)
+}
+
+// call runs the validator with the value, the panic will be recovered as an error if `PanicOnWrongType` was disabled,
+// or only the `ErrWrongType` panic will be recovered if the context was derived from `RecoverWrongType`.
+func call(ctx context.Context, validator Validator, v interface{}) (next context.Context, err error) {
+	switch {
+	case !PanicOnWrongType:
+		defer func() {
+			if r := recover(); r != nil {
+				next, err = ctx, recoveredError(r, v)
+			}
+		}()
+	case ctx.Value(keyRecoverWrongType) == true:
+		defer recoverWrongType(ctx, v, &next, &err)
+	}
+	return validator(ctx, v)
+}
+
+// recoverWrongType recovers the `ErrWrongType` panic as a `ValidationError` of the value, the other panics are propagated as is.
+// It must be deferred directly.
+func recoverWrongType(ctx context.Context, v interface{}, next *context.Context, err *error) {
+	if r := recover(); r != nil {
+		if rerr, ok := r.(error); ok && errors.Is(rerr, ErrWrongType) {
+			*next, *err = ctx, recoveredError(r, v)
+			return
+		}
+		panic(r)
+	}
+}
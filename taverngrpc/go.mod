@@ -0,0 +1,21 @@
+module github.com/teacat/tavern/taverngrpc
+
+go 1.18
+
+require (
+	github.com/stretchr/testify v1.7.0
+	github.com/teacat/tavern v0.0.0-20261017010643-6c6468df5c89
+	golang.org/x/text v0.14.0
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20230525234030-28d5490b6b19
+	google.golang.org/grpc v1.57.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.0 // indirect
+	github.com/golang/protobuf v1.5.3 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/net v0.9.0 // indirect
+	golang.org/x/sys v0.7.0 // indirect
+	google.golang.org/protobuf v1.30.0 // indirect
+	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c // indirect
+)
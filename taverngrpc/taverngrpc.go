@@ -0,0 +1,112 @@
+// Package taverngrpc converts the tavern validation errors to the gRPC statuses with the `BadRequest` details,
+// and provides the server interceptors that validate the incoming messages which implement `tavern.Validatable`.
+package taverngrpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/teacat/tavern"
+	"golang.org/x/text/language"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Status converts the error to a gRPC status, the messages are localized in the language of the tag.
+// The validation errors (a `*tavern.ValidationError` or `tavern.Errors`) are `codes.InvalidArgument` with a `BadRequest` detail that lists every failed field,
+// the message of the status lists the failed fields with their descriptions. The errors of the cancelled contexts are converted by `status.FromContextError`,
+// and the other errors (e.g. a misconfigured validator) are `codes.Internal` with a generic message, so the internal details are not exposed to the clients.
+func Status(err error, tag language.Tag) *status.Status {
+	var errs tavern.Errors
+	if !errors.As(err, &errs) {
+		errs = tavern.Errors{err}
+	}
+	var (
+		violations []*errdetails.BadRequest_FieldViolation
+		messages   []string
+	)
+	for _, e := range errs {
+		var verr *tavern.ValidationError
+		if !errors.As(e, &verr) {
+			continue
+		}
+		description := tavern.Localize(verr, tag)
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       verr.Field,
+			Description: description,
+		})
+		if verr.Field != "" {
+			description = verr.Field + ": " + description
+		}
+		messages = append(messages, description)
+	}
+	if len(violations) == 0 {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return status.FromContextError(err)
+		}
+		return status.New(codes.Internal, "internal error")
+	}
+	st := status.New(codes.InvalidArgument, strings.Join(messages, "; "))
+	if detailed, derr := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); derr == nil {
+		return detailed
+	}
+	return st
+}
+
+// UnaryServerInterceptor validates the requests that implement `tavern.Validatable` before calling the handler,
+// the failures are returned as the status of `Status` which localized in the language of the `accept-language` metadata.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := validate(ctx, req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor validates every received message that implements `tavern.Validatable`, see `UnaryServerInterceptor`.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: ss})
+	}
+}
+
+// serverStream validates the received messages of the wrapped stream.
+type serverStream struct {
+	grpc.ServerStream
+}
+
+// RecvMsg receives the message and validates it.
+func (s *serverStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return validate(s.Context(), m)
+}
+
+// Language returns the most preferred language of the `accept-language` metadata of the incoming context, it's English if the metadata was absent or malformed.
+func Language(ctx context.Context) language.Tag {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if v := md.Get("accept-language"); len(v) != 0 {
+		if tags, _, err := language.ParseAcceptLanguage(v[0]); err == nil && len(tags) != 0 {
+			return tags[0]
+		}
+	}
+	return language.English
+}
+
+// validate validates the message if it implements `tavern.Validatable`, the failures are converted to a status error.
+func validate(ctx context.Context, m interface{}) error {
+	v, ok := m.(tavern.Validatable)
+	if !ok {
+		return nil
+	}
+	if err := tavern.ValidateStructContext(ctx, v); err != nil {
+		return Status(err, Language(ctx)).Err()
+	}
+	return nil
+}
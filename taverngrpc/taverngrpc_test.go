@@ -0,0 +1,117 @@
+package taverngrpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/teacat/tavern"
+	"golang.org/x/text/language"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type testCreateUser struct {
+	Username string
+	Email    string
+}
+
+func (u *testCreateUser) Rules() []tavern.Rule {
+	return []tavern.Rule{
+		tavern.NewNamedRule("username", u.Username, tavern.WithRequired(), tavern.WithLength(3, 20)),
+		tavern.NewNamedRule("email", u.Email, tavern.WithRequired(), tavern.WithEmail()),
+	}
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+	msg *testCreateUser
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testServerStream) RecvMsg(m interface{}) error {
+	*m.(*testCreateUser) = *s.msg
+	return nil
+}
+
+func violationsOf(err error) []*errdetails.BadRequest_FieldViolation {
+	for _, v := range status.Convert(err).Details() {
+		if br, ok := v.(*errdetails.BadRequest); ok {
+			return br.GetFieldViolations()
+		}
+	}
+	return nil
+}
+
+func TestStatus(t *testing.T) {
+	a := assert.New(t)
+	err := tavern.ValidateStruct(&testCreateUser{Username: "ya", Email: "yami"})
+	st := Status(err, language.English)
+	a.Equal(codes.InvalidArgument, st.Code())
+	a.Equal("username: must be between 3 and 20 characters; email: must be a valid email address", st.Message())
+	violations := violationsOf(st.Err())
+	a.Len(violations, 2)
+	a.Equal("username", violations[0].GetField())
+	a.Equal("must be between 3 and 20 characters", violations[0].GetDescription())
+	a.Equal("email", violations[1].GetField())
+
+	st = Status(tavern.Validate(tavern.NewNamedRule("age", 12, tavern.WithRange(18, 130))), language.TraditionalChinese)
+	a.Equal(codes.InvalidArgument, st.Code())
+	a.Len(violationsOf(st.Err()), 1)
+
+	a.Equal(codes.Canceled, Status(context.Canceled, language.English).Code())
+	st = Status(errors.New("database is down"), language.English)
+	a.Equal(codes.Internal, st.Code())
+	a.Equal("internal error", st.Message())
+	st = Status(tavern.NewRule("", tavern.WithLength(5, 1)).Check(), language.English)
+	a.Equal(codes.Internal, st.Code())
+	a.Equal("internal error", st.Message())
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	a := assert.New(t)
+	interceptor := UnaryServerInterceptor()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("accept-language", "zh-TW"))
+
+	resp, err := interceptor(ctx, &testCreateUser{Username: "yami", Email: "yami@example.com"}, &grpc.UnaryServerInfo{}, handler)
+	a.NoError(err)
+	a.Equal("ok", resp)
+	resp, err = interceptor(ctx, "not validatable", &grpc.UnaryServerInfo{}, handler)
+	a.NoError(err)
+	a.Equal("ok", resp)
+
+	resp, err = interceptor(ctx, &testCreateUser{Username: "yami"}, &grpc.UnaryServerInfo{}, handler)
+	a.Nil(resp)
+	a.Equal(codes.InvalidArgument, status.Code(err))
+	violations := violationsOf(err)
+	a.Len(violations, 1)
+	a.Equal("email", violations[0].GetField())
+	a.Equal("為必填", violations[0].GetDescription())
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	a := assert.New(t)
+	interceptor := StreamServerInterceptor()
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		var m testCreateUser
+		return ss.RecvMsg(&m)
+	}
+	ss := &testServerStream{ctx: context.Background(), msg: &testCreateUser{Username: "yami", Email: "yami@example.com"}}
+	a.NoError(interceptor(nil, ss, &grpc.StreamServerInfo{}, handler))
+
+	ss.msg = &testCreateUser{Username: "ya", Email: "yami@example.com"}
+	err := interceptor(nil, ss, &grpc.StreamServerInfo{}, handler)
+	a.Equal(codes.InvalidArgument, status.Code(err))
+	a.Equal("username", violationsOf(err)[0].GetField())
+}
@@ -0,0 +1,88 @@
+package tavernhttp
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/teacat/tavern"
+	"golang.org/x/text/language"
+)
+
+// MediaTypeProblem is the media type of the problem details (RFC 7807).
+const MediaTypeProblem = "application/problem+json"
+
+// Problem is a problem details document (RFC 7807) of a failed request, the failed fields of a validation are listed in the `invalid-params` extension.
+type Problem struct {
+	// Type is a URI that identifies the problem type, it's `about:blank` by default so the title is the HTTP status text.
+	Type string `json:"type"`
+	// Title is a short summary of the problem type.
+	Title string `json:"title"`
+	// Status is the HTTP status code.
+	Status int `json:"status"`
+	// Detail is the explanation of the occurrence of the problem.
+	Detail string `json:"detail,omitempty"`
+	// Instance is a URI that identifies the occurrence of the problem.
+	Instance string `json:"instance,omitempty"`
+	// InvalidParams are the failed fields of the validation.
+	InvalidParams []InvalidParam `json:"invalid-params,omitempty"`
+}
+
+// InvalidParam is a failed field in the `invalid-params` extension of a `Problem`.
+type InvalidParam struct {
+	// Name is the name (or the path) of the field.
+	Name string `json:"name"`
+	// Code is the stable machine code of the validator (e.g. `length`, `email`).
+	Code string `json:"code"`
+	// Reason is the localized message of the failure.
+	Reason string `json:"reason"`
+	// Params are the constraint parameters of the validator (e.g. `min`, `max`).
+	Params tavern.Params `json:"params,omitempty"`
+}
+
+// NewProblem converts the error to a problem, the messages are localized in the language of the tag. The validation errors are `422 Unprocessable Entity`
+// with the invalid params, the errors that wrap `ErrBadRequest` are `400 Bad Request`, and the other errors are `500 Internal Server Error` without the detail.
+func NewProblem(err error, tag language.Tag) *Problem {
+	fields := FieldErrors(err, tag)
+	if len(fields) == 0 {
+		if errors.Is(err, ErrBadRequest) {
+			return newProblem(http.StatusBadRequest, err.Error())
+		}
+		return newProblem(http.StatusInternalServerError, "")
+	}
+	p := newProblem(http.StatusUnprocessableEntity, "")
+	details := make([]string, len(fields))
+	p.InvalidParams = make([]InvalidParam, len(fields))
+	for i, v := range fields {
+		details[i] = v.Message
+		if v.Field != "" {
+			details[i] = v.Field + ": " + v.Message
+		}
+		p.InvalidParams[i] = InvalidParam{Name: v.Field, Code: v.Code, Reason: v.Message, Params: v.Params}
+	}
+	p.Detail = strings.Join(details, "; ")
+	return p
+}
+
+// WriteProblem writes the error as a problem document with the `application/problem+json` content type, see `NewProblem`.
+// The messages are localized in the language of the `Accept-Language` header, and the instance is the path of the request.
+func WriteProblem(w http.ResponseWriter, r *http.Request, err error) {
+	p := NewProblem(err, Language(r))
+	p.Instance = r.URL.Path
+	p.Write(w)
+}
+
+// Write writes the problem as the response with the status of the problem.
+func (p *Problem) Write(w http.ResponseWriter) {
+	writeJSON(w, MediaTypeProblem, p.Status, p)
+}
+
+// newProblem creates a problem of the `about:blank` type with the status.
+func newProblem(status int, detail string) *Problem {
+	return &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
+}
@@ -0,0 +1,192 @@
+// Package tavernhttp binds the values of the HTTP requests (the query string, the forms and the JSON body) and validates them with a tavern schema.
+package tavernhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+
+	"github.com/teacat/tavern"
+	"golang.org/x/text/language"
+)
+
+// DefaultMaxMemory is the maximum bytes of a multipart form that stored in the memory, the rest of the files are stored on the disk.
+const DefaultMaxMemory = 32 << 20
+
+// MaxBodySize is the maximum bytes of the request body that `Values` reads, a larger body is rejected with `ErrBadRequest`.
+// It should be set once before handling the requests, usually in the `init` function.
+var MaxBodySize int64 = 10 << 20
+
+// ErrBadRequest is the error of a request that couldn't be parsed (e.g. a malformed JSON body, an oversized body, an unsupported content type).
+var ErrBadRequest = errors.New("tavern: malformed request")
+
+// FieldError is a failed field in the response of `WriteError`.
+type FieldError struct {
+	// Field is the name (or the path) of the field, it's empty if the rule wasn't named.
+	Field string `json:"field,omitempty"`
+	// Code is the stable machine code of the validator (e.g. `length`, `email`).
+	Code string `json:"code"`
+	// Message is the localized message of the failure.
+	Message string `json:"message"`
+	// Params are the parameters of the validator.
+	Params tavern.Params `json:"params,omitempty"`
+}
+
+// ErrorResponse is the body of the response that written by `WriteError`.
+type ErrorResponse struct {
+	Errors []FieldError `json:"errors"`
+}
+
+// Values reads the values of the request into a map. The query string is read first, then the body is read by it's content type,
+// the URL-encoded forms, the multipart forms (the files are `*multipart.FileHeader`) and the JSON objects are supported, and the values of the body override the query string.
+// A key with a single value is a string, otherwise it's a `[]string`. The numbers of the JSON body are `json.Number`, so they work with `WithInteger` and the ranges.
+// The body is limited to `MaxBodySize`, and a body with a malformed or an unsupported content type is rejected with `ErrBadRequest`.
+func Values(r *http.Request) (map[string]interface{}, error) {
+	return values(r, nil)
+}
+
+// BindAndValidate reads the values of the request (see `Values`) and validates them with the schema, every failure is collected into `tavern.Errors`.
+// The query and form values of the fields that are arrays in the JSON Schema of the schema (e.g. the fields with `WithEach`) are always `[]string` even if there was a single value.
+// The values with a wrong type fail with `tavern.CodeWrongType` instead of panicking regardless of `tavern.PanicOnWrongType`, since the request is untrusted (see `tavern.RecoverWrongType`).
+// The values are returned even if the validation was failed, the error wraps `ErrBadRequest` if the request couldn't be parsed.
+func BindAndValidate(r *http.Request, schema *tavern.Schema) (map[string]interface{}, error) {
+	values, err := values(r, lists(schema))
+	if err != nil {
+		return nil, err
+	}
+	return values, schema.ValidateAllContext(tavern.RecoverWrongType(r.Context()), values)
+}
+
+// values reads the values of the request into a map, the query and form values of the keys in the lists are always `[]string`.
+func values(r *http.Request, lists map[string]bool) (map[string]interface{}, error) {
+	values := make(map[string]interface{})
+	merge(values, r.URL.Query(), lists)
+
+	if r.Body == nil || r.Body == http.NoBody || r.ContentLength == 0 {
+		return values, nil
+	}
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+	r.Body = http.MaxBytesReader(nil, r.Body, MaxBodySize)
+	switch mediaType {
+	case "application/json":
+		body := make(map[string]interface{})
+		decoder := json.NewDecoder(r.Body)
+		decoder.UseNumber()
+		if err := decoder.Decode(&body); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: %v", ErrBadRequest, err)
+		}
+		for k, v := range body {
+			values[k] = v
+		}
+	case "application/x-www-form-urlencoded":
+		if err := r.ParseForm(); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrBadRequest, err)
+		}
+		merge(values, r.PostForm, lists)
+	case "multipart/form-data":
+		if err := r.ParseMultipartForm(DefaultMaxMemory); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrBadRequest, err)
+		}
+		merge(values, r.MultipartForm.Value, lists)
+		for k, v := range r.MultipartForm.File {
+			values[k] = files(v, lists[k])
+		}
+	default:
+		return nil, fmt.Errorf("%w: unsupported content type %q", ErrBadRequest, mediaType)
+	}
+	return values, nil
+}
+
+// FieldErrors converts the validation errors (a `*tavern.ValidationError` or `tavern.Errors`) to the field errors, the messages are localized in the language of the tag.
+// It returns nil if the error wasn't a validation error.
+func FieldErrors(err error, tag language.Tag) []FieldError {
+	var errs tavern.Errors
+	if !errors.As(err, &errs) {
+		errs = tavern.Errors{err}
+	}
+	var fields []FieldError
+	for _, e := range errs {
+		var verr *tavern.ValidationError
+		if !errors.As(e, &verr) {
+			continue
+		}
+		fields = append(fields, FieldError{
+			Field:   verr.Field,
+			Code:    verr.Code,
+			Message: tavern.Localize(verr, tag),
+			Params:  verr.Params,
+		})
+	}
+	return fields
+}
+
+// WriteError writes the error as a JSON response, the validation errors are written with `422 Unprocessable Entity` and the field errors,
+// the messages are localized in the language of the `Accept-Language` header. The errors that wrap `ErrBadRequest` are written with `400 Bad Request`,
+// and the other errors (e.g. a misconfigured validator) are written with `500 Internal Server Error` without exposing the message.
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	if fields := FieldErrors(err, Language(r)); len(fields) != 0 {
+		writeJSON(w, "application/json", http.StatusUnprocessableEntity, ErrorResponse{Errors: fields})
+		return
+	}
+	status, code := http.StatusInternalServerError, "internal_error"
+	message := http.StatusText(status)
+	if errors.Is(err, ErrBadRequest) {
+		status, code, message = http.StatusBadRequest, "bad_request", err.Error()
+	}
+	writeJSON(w, "application/json", status, ErrorResponse{Errors: []FieldError{{Code: code, Message: message}}})
+}
+
+// Language returns the most preferred language of the `Accept-Language` header, it's English if the header was absent or malformed.
+func Language(r *http.Request) language.Tag {
+	tags, _, err := language.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+	if err != nil || len(tags) == 0 {
+		return language.English
+	}
+	return tags[0]
+}
+
+// writeJSON writes the value as the JSON body with the status code.
+func writeJSON(w http.ResponseWriter, contentType string, status int, v interface{}) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// lists returns the names of the fields that are arrays in the JSON Schema of the schema.
+func lists(schema *tavern.Schema) map[string]bool {
+	lists := make(map[string]bool)
+	props, _ := schema.JSONSchema()["properties"].(map[string]interface{})
+	for name, v := range props {
+		if prop, ok := v.(map[string]interface{}); ok && prop["type"] == "array" {
+			lists[name] = true
+		}
+	}
+	return lists
+}
+
+// merge copies the values into the map, a key with a single value is a string unless it's in the lists.
+func merge(dst map[string]interface{}, src url.Values, lists map[string]bool) {
+	for k, v := range src {
+		if len(v) == 1 && !lists[k] {
+			dst[k] = v[0]
+			continue
+		}
+		dst[k] = v
+	}
+}
+
+// files returns the single file header, or the file headers if there were multiple files or the key was a list.
+func files(v []*multipart.FileHeader, list bool) interface{} {
+	if len(v) == 1 && !list {
+		return v[0]
+	}
+	return v
+}
@@ -0,0 +1,220 @@
+package tavernhttp
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/teacat/tavern"
+	"golang.org/x/text/language"
+)
+
+var signup = tavern.MustNewSchema(
+	tavern.Field("username", tavern.WithRequired(), tavern.WithLength(3, 20)),
+	tavern.Field("age", tavern.WithInteger(), tavern.WithRange(18, 130)),
+	tavern.Field("tags", tavern.WithMaxLength(2)),
+	tavern.Field("page", tavern.WithInt(), tavern.WithMinRange(1)),
+)
+
+func TestValues(t *testing.T) {
+	a := assert.New(t)
+	r := httptest.NewRequest(http.MethodGet, "/users?page=2&tags=a&tags=b", nil)
+	values, err := Values(r)
+	a.NoError(err)
+	a.Equal(map[string]interface{}{"page": "2", "tags": []string{"a", "b"}}, values)
+
+	r = httptest.NewRequest(http.MethodPost, "/users?page=2&username=query", strings.NewReader("username=yami&age=20"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	values, err = Values(r)
+	a.NoError(err)
+	a.Equal(map[string]interface{}{"page": "2", "username": "yami", "age": "20"}, values)
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	a.NoError(mw.WriteField("username", "yami"))
+	fw, err := mw.CreateFormFile("avatar", "avatar.png")
+	a.NoError(err)
+	_, _ = fw.Write([]byte("png"))
+	a.NoError(mw.Close())
+	r = httptest.NewRequest(http.MethodPost, "/users", &body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	values, err = Values(r)
+	a.NoError(err)
+	a.Equal("yami", values["username"])
+	a.Equal("avatar.png", values["avatar"].(*multipart.FileHeader).Filename)
+
+	r = httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"username": "yami"`))
+	r.Header.Set("Content-Type", "application/json")
+	_, err = Values(r)
+	a.True(errors.Is(err, ErrBadRequest))
+
+	r = httptest.NewRequest(http.MethodPost, "/users?page=2", nil)
+	values, err = Values(r)
+	a.NoError(err)
+	a.Equal(map[string]interface{}{"page": "2"}, values)
+}
+
+func TestValuesContentType(t *testing.T) {
+	a := assert.New(t)
+	for _, v := range []string{"", "application/json; charset", "text/plain", "application/xml"} {
+		r := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"username": "yami"}`))
+		r.Header.Set("Content-Type", v)
+		_, err := Values(r)
+		a.True(errors.Is(err, ErrBadRequest), v)
+	}
+}
+
+func TestValuesBodySize(t *testing.T) {
+	a := assert.New(t)
+	size := MaxBodySize
+	MaxBodySize = 32
+	defer func() {
+		MaxBodySize = size
+	}()
+
+	r := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"username": "yami"}`))
+	r.Header.Set("Content-Type", "application/json")
+	_, err := Values(r)
+	a.NoError(err)
+
+	bodies := map[string]string{
+		"application/json":                  `{"username": "` + strings.Repeat("a", 64) + `"}`,
+		"application/x-www-form-urlencoded": "username=" + strings.Repeat("a", 64),
+	}
+	for typ, body := range bodies {
+		r = httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(body))
+		r.Header.Set("Content-Type", typ)
+		_, err = Values(r)
+		a.True(errors.Is(err, ErrBadRequest), typ)
+	}
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	a.NoError(mw.WriteField("username", strings.Repeat("a", 64)))
+	a.NoError(mw.Close())
+	r = httptest.NewRequest(http.MethodPost, "/users", &body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	_, err = Values(r)
+	a.True(errors.Is(err, ErrBadRequest))
+}
+
+func TestBindAndValidate(t *testing.T) {
+	a := assert.New(t)
+	r := httptest.NewRequest(http.MethodPost, "/users?page=1", strings.NewReader(`{"username": "yami", "age": 20}`))
+	r.Header.Set("Content-Type", "application/json; charset=utf-8")
+	values, err := BindAndValidate(r, signup)
+	a.NoError(err)
+	a.Equal("yami", values["username"])
+
+	r = httptest.NewRequest(http.MethodPost, "/users?page=-1", strings.NewReader(`{"username": "ya", "age": 20.5}`))
+	r.Header.Set("Content-Type", "application/json")
+	_, err = BindAndValidate(r, signup)
+	var errs tavern.Errors
+	a.True(errors.As(err, &errs))
+	a.Len(errs, 3)
+	a.True(errors.Is(err, tavern.ErrLength))
+	a.True(errors.Is(err, tavern.ErrInteger))
+}
+
+func TestBindAndValidateWrongType(t *testing.T) {
+	a := assert.New(t)
+	profile := tavern.MustNewSchema(
+		tavern.Field("age", tavern.WithInteger()),
+		tavern.Field("email", tavern.WithEmail()),
+		tavern.Field("address", tavern.WithObject(tavern.MustNewSchema(tavern.Field("city", tavern.WithRequired())))),
+		tavern.Field("tags", tavern.WithEach(tavern.WithAlpha())),
+	)
+	codes := func(err error) map[string]string {
+		var errs tavern.Errors
+		a.True(errors.As(err, &errs))
+		codes := make(map[string]string)
+		for _, e := range errs {
+			verr := e.(*tavern.ValidationError)
+			codes[verr.Field] = verr.Code
+		}
+		return codes
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/users?tag=abc&tags=abc", strings.NewReader("age=20"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	values, err := BindAndValidate(r, profile)
+	a.Equal(map[string]string{"age": tavern.CodeWrongType}, codes(err))
+	a.Equal([]string{"abc"}, values["tags"])
+	a.Equal("abc", values["tag"])
+
+	r = httptest.NewRequest(http.MethodGet, "/users?tags=abc&tags=1", nil)
+	_, err = BindAndValidate(r, profile)
+	a.Equal(map[string]string{"tags[1]": tavern.CodeAlpha}, codes(err))
+
+	r = httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"email": true, "address": "oops", "tags": "abc"}`))
+	r.Header.Set("Content-Type", "application/json")
+	_, err = BindAndValidate(r, profile)
+	a.Equal(map[string]string{"email": tavern.CodeWrongType, "address": tavern.CodeWrongType, "tags": tavern.CodeWrongType}, codes(err))
+
+	w := httptest.NewRecorder()
+	WriteError(w, r, err)
+	a.Equal(http.StatusUnprocessableEntity, w.Code)
+	a.Contains(w.Body.String(), `{"field":"email","code":"wrong_type","message":"must be a string but got bool","params":{"actual":"bool","expected":"string"}}`)
+}
+
+func TestWriteError(t *testing.T) {
+	a := assert.New(t)
+	r := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"username": "ya"}`))
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Accept-Language", "zh-TW,zh;q=0.9,en;q=0.8")
+	_, err := BindAndValidate(r, signup)
+
+	w := httptest.NewRecorder()
+	WriteError(w, r, err)
+	a.Equal(http.StatusUnprocessableEntity, w.Code)
+	a.Equal("application/json", w.Header().Get("Content-Type"))
+	a.JSONEq(`{"errors": [{"field": "username", "code": "length", "message": "長度必須介於 3 到 20 個字元之間", "params": {"min": 3, "max": 20}}]}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	WriteError(w, r, ErrBadRequest)
+	a.Equal(http.StatusBadRequest, w.Code)
+	a.JSONEq(`{"errors": [{"code": "bad_request", "message": "tavern: malformed request"}]}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	WriteError(w, r, tavern.ErrInvalidConfig)
+	a.Equal(http.StatusInternalServerError, w.Code)
+	a.JSONEq(`{"errors": [{"code": "internal_error", "message": "Internal Server Error"}]}`, w.Body.String())
+}
+
+func TestProblem(t *testing.T) {
+	a := assert.New(t)
+	r := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"username": "ya", "age": 12}`))
+	r.Header.Set("Content-Type", "application/json")
+	_, err := BindAndValidate(r, signup)
+
+	w := httptest.NewRecorder()
+	WriteProblem(w, r, err)
+	a.Equal(http.StatusUnprocessableEntity, w.Code)
+	a.Equal("application/problem+json", w.Header().Get("Content-Type"))
+	a.JSONEq(`{
+		"type": "about:blank",
+		"title": "Unprocessable Entity",
+		"status": 422,
+		"detail": "username: must be between 3 and 20 characters; age: must be between 18 and 130",
+		"instance": "/users",
+		"invalid-params": [
+			{"name": "username", "code": "length", "reason": "must be between 3 and 20 characters", "params": {"min": 3, "max": 20}},
+			{"name": "age", "code": "range", "reason": "must be between 18 and 130", "params": {"min": 18, "max": 130}}
+		]
+	}`, w.Body.String())
+
+	p := NewProblem(tavern.Validate(tavern.NewNamedRule("email", "yami", tavern.WithEmail())), language.TraditionalChinese)
+	a.Equal(http.StatusUnprocessableEntity, p.Status)
+	a.Len(p.InvalidParams, 1)
+	a.Equal("email", p.InvalidParams[0].Name)
+
+	p = NewProblem(ErrBadRequest, language.English)
+	a.Equal(&Problem{Type: "about:blank", Title: "Bad Request", Status: http.StatusBadRequest, Detail: "tavern: malformed request"}, p)
+	p = NewProblem(errors.New("database is down"), language.English)
+	a.Equal(&Problem{Type: "about:blank", Title: "Internal Server Error", Status: http.StatusInternalServerError}, p)
+}
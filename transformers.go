@@ -0,0 +1,120 @@
+package tavern
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// regExpSpaces matches the consecutive white spaces.
+var regExpSpaces = regexp.MustCompile(`[\s\p{Zs}]+`)
+
+// transformed stores the transformed value into the context, so the later validators receive the transformed value.
+func transformed(ctx context.Context, v interface{}) context.Context {
+	return context.WithValue(ctx, KeyValue, v)
+}
+
+// valueOf returns the transformed value in the context, or the value itself if it wasn't transformed.
+func valueOf(ctx context.Context, v interface{}) interface{} {
+	if t := ctx.Value(KeyValue); t != nil {
+		return t
+	}
+	return v
+}
+
+// assign writes the transformed value back into the pointer target, the `sql.Scanner` targets (e.g. `*sql.NullString`) scan the value.
+// The value is discarded if the target wasn't a non-nil pointer or the types were incompatible.
+func assign(target, v interface{}) {
+	ptr := reflect.ValueOf(target)
+	if ptr.Kind() != reflect.Ptr || ptr.IsNil() {
+		return
+	}
+	for ptr.Elem().Kind() == reflect.Ptr && !ptr.Elem().IsNil() {
+		ptr = ptr.Elem()
+	}
+	if s, ok := ptr.Interface().(sql.Scanner); ok {
+		_ = s.Scan(v)
+		return
+	}
+	elem, value := ptr.Elem(), reflect.ValueOf(v)
+	switch {
+	case value.Type().AssignableTo(elem.Type()):
+		elem.Set(value)
+	case value.Kind() == elem.Kind() && value.Type().ConvertibleTo(elem.Type()):
+		elem.Set(value.Convert(elem.Type()))
+	}
+}
+
+// stringTransformer creates a transformer that transforms the string value with the function, the absent values are left as is.
+func stringTransformer(fn func(string) string) Validator {
+	return func(ctx context.Context, v interface{}) (context.Context, error) {
+		v = indirect(v)
+		if v == nil {
+			return ctx, nil
+		}
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		return transformed(ctx, fn(k)), nil
+	}
+}
+
+// WithTrim removes the leading and the trailing white spaces of the value, so `WithRequired` fails on a blank string after it.
+func WithTrim() Validator {
+	return stringTransformer(strings.TrimSpace)
+}
+
+// WithLowercase converts the value to lowercase (e.g. before `WithEmail`).
+func WithLowercase() Validator {
+	return stringTransformer(strings.ToLower)
+}
+
+// WithNFC normalizes the value to the Unicode Normalization Form C, so the composed and the decomposed characters have the same length.
+func WithNFC() Validator {
+	return stringTransformer(norm.NFC.String)
+}
+
+// WithCollapseSpaces replaces the consecutive white spaces of the value with a single space.
+func WithCollapseSpaces() Validator {
+	return stringTransformer(func(s string) string {
+		return regExpSpaces.ReplaceAllString(s, " ")
+	})
+}
+
+// WithText converts the value that implements `encoding.TextMarshaler` or `fmt.Stringer` (e.g. `net.IP`, a domain ID type) to it's text,
+// so the later string validators (e.g. `WithPrefix`) validate the text. The string validators report a wrong type for these values without it.
+// The strings (including the named string types) are left as is, and so are the absent values.
+func WithText() Validator {
+	return func(ctx context.Context, v interface{}) (context.Context, error) {
+		if isNotRequiredAndZeroValue(ctx, v) {
+			return ctx, nil
+		}
+		if _, ok := stringOf(indirect(v)); ok {
+			return ctx, nil
+		}
+		k, ok := textOf(v)
+		if !ok {
+			k, ok = textOf(indirect(v))
+		}
+		if !ok {
+			return ctx, wrongType(kindString, indirect(v))
+		}
+		return transformed(ctx, k), nil
+	}
+}
+
+// WithDefault replaces the value with the default value if it was absent or a zero value.
+func WithDefault(value interface{}) Validator {
+	return describe(description{code: codeDefault, params: Params{"value": value}}, func(ctx context.Context, v interface{}) (context.Context, error) {
+		v = indirect(v)
+		if v == nil || reflect.ValueOf(v).IsZero() {
+			return transformed(ctx, value), nil
+		}
+		return ctx, nil
+	})
+}
@@ -2,10 +2,14 @@ package tavern
 
 import (
 	"context"
+	"database/sql"
+	"encoding"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
 	"net"
+	"net/url"
 	"reflect"
 	"regexp"
 	"strconv"
@@ -36,348 +40,492 @@ var (
 	ErrURL = errors.New("tavern: invalid url format")
 	// ErrJSON is invalid json format.
 	ErrJSON = errors.New("tavern: invalid json format")
+	// ErrNotEqual is not equal to the other value.
+	ErrNotEqual = errors.New("tavern: not equal to the other value")
+	// ErrComparison is failed to compare with the other value.
+	ErrComparison = errors.New("tavern: failed the comparison with the other value")
+	// ErrExcluded is the value must be absent.
+	ErrExcluded = errors.New("tavern: value must be absent")
+	// ErrUnknownField is the field was not declared in the schema.
+	ErrUnknownField = errors.New("tavern: unknown field")
+	// ErrNotOneOf is not one of the allowed values.
+	ErrNotOneOf = errors.New("tavern: not one of the allowed values")
+	// ErrOneOf is one of the disallowed values.
+	ErrOneOf = errors.New("tavern: one of the disallowed values")
+	// ErrAnyOf is none of the alternatives passed.
+	ErrAnyOf = errors.New("tavern: none of the alternatives passed")
+	// ErrExactlyOneOf is not exactly one of the alternatives passed.
+	ErrExactlyOneOf = errors.New("tavern: not exactly one of the alternatives passed")
+	// ErrNot is passed the negated validator.
+	ErrNot = errors.New("tavern: passed the negated validator")
 )
 
 var (
 	// ErrWrongType is passed a wrong value type to validator.
 	ErrWrongType = errors.New("tavern: passed wrong value type to validator")
+	// ErrPanic is a validator panicked while validating the value.
+	ErrPanic = errors.New("tavern: validator panicked")
+	// ErrInvalidConfig is the validator was misconfigured (e.g. the minimum is greater than the maximum, an invalid pattern).
+	ErrInvalidConfig = errors.New("tavern: invalid validator configuration")
 )
 
+// PanicOnWrongType panics with `ErrWrongType` when a value with the wrong type was passed to a built-in validator, it's enabled by default.
+// Disable it to return a `ValidationError` with `CodeWrongType` instead, the panics in the custom validators will also be recovered and returned as `ErrPanic`.
+// It should be set once before validating, usually in the `init` function.
+var PanicOnWrongType = true
+
+const (
+	// kindString is the expected kind of the string validators.
+	kindString = "string"
+	// kindBytes is the expected kind of the validators that accept both string and bytes.
+	kindBytes = "string or []byte"
+	// kindNumber is the expected kind of the number validators.
+	kindNumber = "number"
+	// kindLength is the expected kind of the validators that count the length.
+	kindLength = "string, slice, array, map, chan or number"
+	// kindList is the expected kind of the validators that iterate the elements.
+	kindList = "slice or array"
+	// kindMap is the expected kind of the validators that iterate the keys or the values.
+	kindMap = "map"
+	// kindComparable is the expected kind of the validators that compare the values.
+	kindComparable = "number, string or time.Time"
+)
+
+// wrongType panics with `ErrWrongType` if `PanicOnWrongType` was enabled, otherwise it returns a `ValidationError` that names the expected and the actual kinds.
+func wrongType(expected string, v interface{}) error {
+	actual := "nil"
+	if v != nil {
+		actual = reflect.TypeOf(v).Kind().String()
+	}
+	params := Params{"expected": expected, "actual": actual}
+	if PanicOnWrongType {
+		panic(&wrongTypeError{params: params})
+	}
+	return newError(CodeWrongType, ErrWrongType, v, params)
+}
+
+// wrongTypeError is the panic of `wrongType`, it matches `ErrWrongType` via `errors.Is` and keeps the kinds so the recovered error has the same parameters.
+type wrongTypeError struct {
+	params Params
+}
+
+// Error returns the message of `ErrWrongType`.
+func (e *wrongTypeError) Error() string {
+	return ErrWrongType.Error()
+}
+
+// Unwrap returns `ErrWrongType`.
+func (e *wrongTypeError) Unwrap() error {
+	return ErrWrongType
+}
+
 // Key represents the keys in the context.
 type Key int
 
 const (
 	// KeyRequired returns true if the value was set to required. The validators can rely on the value with it's own logic.
 	KeyRequired Key = iota
+	// KeyValues returns the values of the named rules in the same validation as a `map[string]interface{}`, the cross-field validators rely on it.
+	KeyValues
+	// KeyValue returns the transformed value of the rule, the transformers (e.g. `WithTrim`) store the value with it so the later validators receive the transformed value.
+	KeyValue
+	// keyPresent marks the value as present even if it was a zero value, the coercers set it so a parsed zero (e.g. `"0"` to `0`) is still validated.
+	keyPresent
+	// keyRecoverWrongType makes the validators return `ErrWrongType` instead of panicking, see `RecoverWrongType`.
+	keyRecoverWrongType
 )
 
-// isNotRequiredAndZeroValue 表示這個欄位是不是非必要而且還零值。
+// indirect dereferences the pointers and the interfaces of the value at any depth and unwraps the `database/sql` null types,
+// it returns nil if the value was nil, a nil pointer or an invalid null type (e.g. `sql.NullString{Valid: false}`).
+func indirect(v interface{}) interface{} {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if !value.IsValid() {
+		return nil
+	}
+	switch k := value.Interface().(type) {
+	case sql.NullString:
+		return nullable(k.String, k.Valid)
+	case sql.NullInt64:
+		return nullable(k.Int64, k.Valid)
+	case sql.NullInt32:
+		return nullable(k.Int32, k.Valid)
+	case sql.NullInt16:
+		return nullable(k.Int16, k.Valid)
+	case sql.NullByte:
+		return nullable(k.Byte, k.Valid)
+	case sql.NullFloat64:
+		return nullable(k.Float64, k.Valid)
+	case sql.NullBool:
+		return nullable(k.Bool, k.Valid)
+	case sql.NullTime:
+		return nullable(k.Time, k.Valid)
+	default:
+		return k
+	}
+}
+
+// nullable returns the value if it's valid, nil otherwise.
+func nullable(v interface{}, valid bool) interface{} {
+	if !valid {
+		return nil
+	}
+	return v
+}
+
+// stringOf returns the string of the value, it accepts the named string types (e.g. `type Email string`).
+// It returns false if the value cannot be a string, the `fmt.Stringer` values are only accepted after `WithText`.
+func stringOf(v interface{}) (string, bool) {
+	value := reflect.ValueOf(v)
+	if value.Kind() == reflect.String {
+		return value.String(), true
+	}
+	return "", false
+}
+
+// textOf returns the text of the value that implements `encoding.TextMarshaler` or `fmt.Stringer`.
+func textOf(v interface{}) (string, bool) {
+	switch k := v.(type) {
+	case encoding.TextMarshaler:
+		b, err := k.MarshalText()
+		if err != nil {
+			return "", false
+		}
+		return string(b), true
+	case fmt.Stringer:
+		return k.String(), true
+	}
+	return "", false
+}
+
+// isNotRequiredAndZeroValue 表示這個欄位是不是非必要而且還零值，或是根本不存在（nil、nil 指標）。
 func isNotRequiredAndZeroValue(ctx context.Context, v interface{}) bool {
+	v = indirect(v)
+	if v == nil {
+		return true
+	}
+	if _, ok := ctx.Value(keyPresent).(bool); ok {
+		return false
+	}
 	_, ok := ctx.Value(KeyRequired).(bool)
 	return !ok && reflect.ValueOf(v).IsZero()
 }
 
+// lengthOf returns the length of the value (e.g. slice, string), it counts the length of the number if the value was a number.
+// The floats are formatted without the exponent, so the integral float (e.g. `1e+06` from a decoded JSON) has the same length as the integer.
+func lengthOf(v interface{}) (int, error) {
+	value := reflect.ValueOf(v)
+	switch value.Kind() {
+	case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
+		return value.Len(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return len(strconv.FormatInt(value.Int(), 10)), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return len(strconv.FormatUint(value.Uint(), 10)), nil
+	case reflect.Float32:
+		return len(strconv.FormatFloat(value.Float(), 'f', -1, 32)), nil
+	case reflect.Float64:
+		return len(strconv.FormatFloat(value.Float(), 'f', -1, 64)), nil
+	default:
+		return 0, wrongType(kindLength, v)
+	}
+}
+
 // WithRequired requires the value to not be a zero value (e.g. 0, "") nor an empty value.
 func WithRequired() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeRequired}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		ctx = context.WithValue(ctx, KeyRequired, true)
-		value := reflect.ValueOf(v)
-		if value.IsZero() {
-			return ctx, ErrRequired
+		v = indirect(v)
+		if v == nil || reflect.ValueOf(v).IsZero() {
+			return ctx, newError(CodeRequired, ErrRequired, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithLength requires the length of the value (e.g. slive, string, number) to be in a certian length. It counts the length of the number if the value was a number.
 func WithLength(min, max int) Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeLength, params: Params{"min": min, "max": max}, err: checkLength(min, max)}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
-
-		var err error
-		ctx, err = WithMinLength(min)(ctx, v)
+		v = indirect(v)
+		l, err := lengthOf(v)
 		if err != nil {
 			return ctx, err
 		}
-		ctx, err = WithMaxLength(max)(ctx, v)
-		if err != nil {
-			return ctx, err
+		if l < min || l > max {
+			return ctx, newError(CodeLength, ErrLength, v, Params{"min": min, "max": max})
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithMaxLength requires the length of the value (e.g. slive, string, number) cannot be too long. It counts the length of the number if the value was a number.
 func WithMaxLength(max int) Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeMaxLength, params: Params{"max": max}, err: checkLength(0, max)}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
-
-		value := reflect.ValueOf(v)
-		switch value.Kind() {
-		case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
-			if value.Len() > max {
-				return ctx, ErrLength
-			}
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			str := strconv.Itoa(int(value.Int()))
-			if len(str) > max {
-				return ctx, ErrLength
-			}
-		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			str := strconv.Itoa(int(value.Uint()))
-			if len(str) > max {
-				return ctx, ErrLength
-			}
-		case reflect.Float32, reflect.Float64:
-			str := fmt.Sprintf("%g", value.Float())
-			if len(str) > max {
-				return ctx, ErrLength
-			}
-		default:
-			panic(ErrWrongType)
+		v = indirect(v)
+		l, err := lengthOf(v)
+		if err != nil {
+			return ctx, err
+		}
+		if l > max {
+			return ctx, newError(CodeMaxLength, ErrLength, v, Params{"max": max})
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithMinLength requires the length of the value (e.g. slive, string, number) cannot be too short. It counts the length of the number if the value was a number.
 func WithMinLength(min int) Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeMinLength, params: Params{"min": min}, err: checkLength(min, min)}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
-
-		value := reflect.ValueOf(v)
-		switch value.Kind() {
-		case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
-			if value.Len() < min {
-				return ctx, ErrLength
-			}
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			str := strconv.Itoa(int(value.Int()))
-			if len(str) < min {
-				return ctx, ErrLength
-			}
-		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			str := strconv.Itoa(int(value.Uint()))
-			if len(str) < min {
-				return ctx, ErrLength
-			}
-		case reflect.Float32, reflect.Float64:
-			str := fmt.Sprintf("%g", value.Float())
-			if len(str) < min {
-				return ctx, ErrLength
-			}
-		default:
-			panic(ErrWrongType)
+		v = indirect(v)
+		l, err := lengthOf(v)
+		if err != nil {
+			return ctx, err
+		}
+		if l < min {
+			return ctx, newError(CodeMinLength, ErrLength, v, Params{"min": min})
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithFixedLength requires the length of the value (e.g. slive, string, number) to be the exact length. It counts the length of the number if the value was a number.
 func WithFixedLength(length int) Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeFixedLength, params: Params{"length": length}, err: checkLength(length, length)}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
-
-		var err error
-		ctx, err = WithMinLength(length)(ctx, v)
+		v = indirect(v)
+		l, err := lengthOf(v)
 		if err != nil {
 			return ctx, err
 		}
-		ctx, err = WithMaxLength(length)(ctx, v)
-		if err != nil {
-			return ctx, err
+		if l != length {
+			return ctx, newError(CodeFixedLength, ErrLength, v, Params{"length": length})
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithRange requires the number of the value to be in a certian range.
 func WithRange(min, max int) Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
-		if isNotRequiredAndZeroValue(ctx, v) {
-			return ctx, nil
-		}
-
-		value := reflect.ValueOf(v)
-		switch value.Kind() {
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			if value.Int() < int64(min) || value.Int() > int64(max) {
-				return ctx, ErrRange
-			}
-		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			if value.Uint() < uint64(min) || value.Uint() > uint64(max) {
-				return ctx, ErrRange
-			}
-		case reflect.Float32, reflect.Float64:
-			if value.Float() < float64(min) || value.Float() > float64(max) {
-				return ctx, ErrRange
-			}
-		default:
-			panic(ErrWrongType)
-		}
-		return ctx, nil
-	}
+	return WithRangeOf(min, max)
 }
 
 // WithMaxRange requires the number of the value to be equal or less than the specified number.
 func WithMaxRange(max int) Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
-		if isNotRequiredAndZeroValue(ctx, v) {
-			return ctx, nil
-		}
-
-		value := reflect.ValueOf(v)
-		switch value.Kind() {
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			if value.Int() > int64(max) {
-				return ctx, ErrRange
-			}
-		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			if value.Uint() > uint64(max) {
-				return ctx, ErrRange
-			}
-		case reflect.Float32, reflect.Float64:
-			if value.Float() > float64(max) {
-				return ctx, ErrRange
-			}
-		default:
-			panic(ErrWrongType)
-		}
-		return ctx, nil
-	}
+	return WithMaxRangeOf(max)
 }
 
 // WithMinRange requires the number of the value to be least equal or greater than the specified number.
 func WithMinRange(min int) Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
-		if isNotRequiredAndZeroValue(ctx, v) {
-			return ctx, nil
-		}
+	return WithMinRangeOf(min)
+}
 
-		value := reflect.ValueOf(v)
-		switch value.Kind() {
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			if value.Int() < int64(min) {
-				return ctx, ErrRange
-			}
-		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			if value.Uint() < uint64(min) {
-				return ctx, ErrRange
-			}
-		case reflect.Float32, reflect.Float64:
-			if value.Float() < float64(min) {
-				return ctx, ErrRange
-			}
-		default:
-			panic(ErrWrongType)
-		}
-		return ctx, nil
-	}
+// WithRangeOf requires the number of the value to be in a certian range, the bounds can be any number type and the comparison is lossless.
+// The bounds are inclusive unless `ExclusiveMin`, `ExclusiveMax` or `Exclusive` was passed, NaN is never in the range.
+func WithRangeOf[T Numeric](min, max T, bounds ...Bound) Validator {
+	b := boundOf(bounds)
+	return rangeValidator(CodeRange, newLimit(min, b&ExclusiveMin != 0), newLimit(max, b&ExclusiveMax != 0), rangeParams(min, max, b))
+}
+
+// WithMaxRangeOf requires the number of the value to be equal or less than the specified number of any number type, pass `ExclusiveMax` to exclude the number itself.
+func WithMaxRangeOf[T Numeric](max T, bounds ...Bound) Validator {
+	b := boundOf(bounds) & ExclusiveMax
+	return rangeValidator(CodeMaxRange, nil, newLimit(max, b != 0), rangeParams(nil, max, b))
+}
+
+// WithMinRangeOf requires the number of the value to be equal or greater than the specified number of any number type, pass `ExclusiveMin` to exclude the number itself.
+func WithMinRangeOf[T Numeric](min T, bounds ...Bound) Validator {
+	b := boundOf(bounds) & ExclusiveMin
+	return rangeValidator(CodeMinRange, newLimit(min, b != 0), nil, rangeParams(min, nil, b))
+}
+
+// WithRangeFloat is the `float64` version of `WithRangeOf`.
+func WithRangeFloat(min, max float64, bounds ...Bound) Validator {
+	return WithRangeOf(min, max, bounds...)
+}
+
+// WithMaxRangeFloat is the `float64` version of `WithMaxRangeOf`.
+func WithMaxRangeFloat(max float64, bounds ...Bound) Validator {
+	return WithMaxRangeOf(max, bounds...)
+}
+
+// WithMinRangeFloat is the `float64` version of `WithMinRangeOf`.
+func WithMinRangeFloat(min float64, bounds ...Bound) Validator {
+	return WithMinRangeOf(min, bounds...)
+}
+
+// WithRangeInt64 is the `int64` version of `WithRangeOf`.
+func WithRangeInt64(min, max int64, bounds ...Bound) Validator {
+	return WithRangeOf(min, max, bounds...)
+}
+
+// WithMaxRangeInt64 is the `int64` version of `WithMaxRangeOf`.
+func WithMaxRangeInt64(max int64, bounds ...Bound) Validator {
+	return WithMaxRangeOf(max, bounds...)
+}
+
+// WithMinRangeInt64 is the `int64` version of `WithMinRangeOf`.
+func WithMinRangeInt64(min int64, bounds ...Bound) Validator {
+	return WithMinRangeOf(min, bounds...)
+}
+
+// WithRangeUint64 is the `uint64` version of `WithRangeOf`.
+func WithRangeUint64(min, max uint64, bounds ...Bound) Validator {
+	return WithRangeOf(min, max, bounds...)
+}
+
+// WithMaxRangeUint64 is the `uint64` version of `WithMaxRangeOf`.
+func WithMaxRangeUint64(max uint64, bounds ...Bound) Validator {
+	return WithMaxRangeOf(max, bounds...)
+}
+
+// WithMinRangeUint64 is the `uint64` version of `WithMinRangeOf`.
+func WithMinRangeUint64(min uint64, bounds ...Bound) Validator {
+	return WithMinRangeOf(min, bounds...)
 }
 
 // WithMaximum requires the length of the slice, string and the range of the number to be least equal or less than the specified number.
 func WithMaximum(max int) Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
-		if isNotRequiredAndZeroValue(ctx, v) {
-			return ctx, nil
-		}
-
-		value := reflect.ValueOf(v)
-		switch value.Kind() {
-		case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
-			if value.Len() > max {
-				return ctx, ErrLength
-			}
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			if value.Int() > int64(max) {
-				return ctx, ErrRange
-			}
-		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			if value.Uint() > uint64(max) {
-				return ctx, ErrRange
-			}
-		case reflect.Float32, reflect.Float64:
-			if value.Float() > float64(max) {
-				return ctx, ErrRange
-			}
-		default:
-			panic(ErrWrongType)
-		}
-		return ctx, nil
-	}
+	return WithMaximumOf(max)
 }
 
 // WithMinimum requires the length of the slice, string and the range of the number to be least equal or greater than the specified number.
 func WithMinimum(min int) Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return WithMinimumOf(min)
+}
+
+// WithMaximumOf is the same as `WithMaximum` but the bound can be any number type and the comparison is lossless, pass `ExclusiveMax` to exclude the number itself.
+func WithMaximumOf[T Numeric](max T, bounds ...Bound) Validator {
+	b := boundOf(bounds) & ExclusiveMax
+	return boundValidator(CodeMaximum, nil, newLimit(max, b != 0), rangeParams(nil, max, b))
+}
+
+// WithMinimumOf is the same as `WithMinimum` but the bound can be any number type and the comparison is lossless, pass `ExclusiveMin` to exclude the number itself.
+func WithMinimumOf[T Numeric](min T, bounds ...Bound) Validator {
+	b := boundOf(bounds) & ExclusiveMin
+	return boundValidator(CodeMinimum, newLimit(min, b != 0), nil, rangeParams(min, nil, b))
+}
+
+// boundValidator creates a validator that requires the length of the slice, string or the number of the value to be within the limits.
+func boundValidator(code string, min, max *limit, params Params) Validator {
+	return describe(description{code: code, params: params, err: checkRange(min, max)}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
 		value := reflect.ValueOf(v)
 		switch value.Kind() {
 		case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
-			if value.Len() < min {
-				return ctx, ErrLength
+			if !within(new(big.Float).SetInt64(int64(value.Len())), min, max) {
+				return ctx, newError(code, ErrLength, v, params)
 			}
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			if value.Int() < int64(min) {
-				return ctx, ErrRange
-			}
-		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			if value.Uint() < uint64(min) {
-				return ctx, ErrRange
+		default:
+			n, ok := numberOf(v)
+			if !ok {
+				return ctx, wrongType(kindLength, v)
 			}
-		case reflect.Float32, reflect.Float64:
-			if value.Float() < float64(min) {
-				return ctx, ErrRange
+			if !within(n, min, max) {
+				return ctx, newError(code, ErrRange, v, params)
 			}
-		default:
-			panic(ErrWrongType)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithDatetime requires the date format to match the Golang date format. It validates via the `time.Parse` function.
 func WithDatetime(f string) Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeDatetime, params: Params{"format": f}}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			t, err := time.Parse(f, k)
-			if err != nil {
-				return ctx, err
-			}
-			if t.Format(f) != k {
-				return ctx, ErrDatetime
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		t, err := time.Parse(f, k)
+		if err != nil || t.Format(f) != k {
+			return ctx, newError(CodeDatetime, ErrDatetime, v, Params{"format": f})
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithEmail requires the value to be an email. It validates with a built-in email regexp pattern.
 func WithEmail() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeEmail}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			if !regExpEmailRegex.Match([]byte(k)) {
-				return ctx, ErrEmail
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		if !regExpEmailRegex.MatchString(k) {
+			return ctx, newError(CodeEmail, ErrEmail, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
-//
-/*func WithOneOf() {
+// WithOneOf requires the value to be one of the specified values, the numbers, the strings and the `time.Time` values are compared by their underlying values.
+func WithOneOf(values ...interface{}) Validator {
+	return describe(description{code: CodeOneOf, params: Params{"values": values}, err: checkValues(values)}, func(ctx context.Context, v interface{}) (context.Context, error) {
+		if isNotRequiredAndZeroValue(ctx, v) {
+			return ctx, nil
+		}
+		v = indirect(v)
 
-}*/
+		if !containsValue(values, v) {
+			return ctx, newError(CodeOneOf, ErrNotOneOf, v, Params{"values": values})
+		}
+		return ctx, nil
+	})
+}
 
-//
-/*func WithNotOneOf() {
+// WithNotOneOf requires the value to not be any of the specified values, the values are compared like `WithOneOf`.
+func WithNotOneOf(values ...interface{}) Validator {
+	return describe(description{code: CodeNotOneOf, params: Params{"values": values}, err: checkValues(values)}, func(ctx context.Context, v interface{}) (context.Context, error) {
+		if isNotRequiredAndZeroValue(ctx, v) {
+			return ctx, nil
+		}
+		v = indirect(v)
 
-}*/
+		if containsValue(values, v) {
+			return ctx, newError(CodeNotOneOf, ErrOneOf, v, Params{"values": values})
+		}
+		return ctx, nil
+	})
+}
+
+// containsValue reports whether the value equals to any of the values.
+func containsValue(values []interface{}, v interface{}) bool {
+	for _, j := range values {
+		if equalValues(v, j) {
+			return true
+		}
+	}
+	return false
+}
 
 //
 /*func WithIP() {
@@ -394,15 +542,40 @@ func WithEmail() Validator {
 
 }*/
 
-//
-/*func WithURL() {
+// WithURL requires the value to be an absolute URL with a scheme and a host (e.g. `https://example.com`, `postgres://localhost:5432/db`).
+func WithURL() Validator {
+	return describe(description{code: CodeURL}, func(ctx context.Context, v interface{}) (context.Context, error) {
+		if isNotRequiredAndZeroValue(ctx, v) {
+			return ctx, nil
+		}
+		v = indirect(v)
 
-}*/
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		u, err := url.Parse(k)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return ctx, newError(CodeURL, ErrURL, v, nil)
+		}
+		return ctx, nil
+	})
+}
 
-//
-/*func WithEqual() {
+// WithEqual requires the value to be equal to the specified value, the numbers, the strings and the `time.Time` values are compared by their underlying values.
+func WithEqual(value interface{}) Validator {
+	return describe(description{code: CodeEqual, params: Params{"expected": value}}, func(ctx context.Context, v interface{}) (context.Context, error) {
+		if isNotRequiredAndZeroValue(ctx, v) {
+			return ctx, nil
+		}
+		v = indirect(v)
 
-}*/
+		if !equalValues(v, value) {
+			return ctx, newError(CodeEqual, ErrNotEqual, v, Params{"expected": value})
+		}
+		return ctx, nil
+	})
+}
 
 //
 /*func WithNotEqual() {
@@ -420,156 +593,175 @@ func WithEmail() Validator {
 }*/
 
 // WithRegExp validates the valiue with specified regular expression.
+// The pattern is compiled once, an invalid pattern is reported by `Rule.Check` and the validation, see `CompileRegExp`.
 func WithRegExp(r string) Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	v, _ := CompileRegExp(r)
+	return v
+}
+
+// CompileRegExp creates a `WithRegExp` validator and returns the error which wraps `ErrInvalidConfig` if the pattern was invalid.
+func CompileRegExp(r string) (Validator, error) {
+	re, err := regexp.Compile(r)
+	if err != nil {
+		err = invalidConfig("%v", err)
+	}
+	return describe(description{code: CodeRegExp, params: Params{"pattern": r}, err: err}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			m, err := regexp.Match(r, []byte(k))
-			if !m || err != nil {
-				return ctx, ErrInvalidPattern
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		if !re.MatchString(k) {
+			return ctx, newError(CodeRegExp, ErrInvalidPattern, v, Params{"pattern": r})
 		}
 		return ctx, nil
+	}), err
+}
+
+// MustWithRegExp is the same as `WithRegExp` but panics if the pattern was invalid, it's useful to declare the validator as a package variable.
+func MustWithRegExp(r string) Validator {
+	v, err := CompileRegExp(r)
+	if err != nil {
+		panic(err)
 	}
+	return v
 }
 
 // WithPrefix requires the value started with a specified sentence.
 func WithPrefix(p string) Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodePrefix, params: Params{"prefix": p}}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			if !strings.HasPrefix(k, p) {
-				return ctx, ErrInvalidPattern
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		if !strings.HasPrefix(k, p) {
+			return ctx, newError(CodePrefix, ErrInvalidPattern, v, Params{"prefix": p})
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithSuffix requires the value ended with a specified sentence.
 func WithSuffix(s string) Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeSuffix, params: Params{"suffix": s}}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			if !strings.HasSuffix(k, s) {
-				return ctx, ErrInvalidPattern
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		if !strings.HasSuffix(k, s) {
+			return ctx, newError(CodeSuffix, ErrInvalidPattern, v, Params{"suffix": s})
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithAlpha requires the value to be alphabets only.
 func WithAlpha() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeAlpha}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			if !regExpAlphaRegex.Match([]byte(k)) {
-				return ctx, ErrInvalidPattern
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		if !regExpAlphaRegex.MatchString(k) {
+			return ctx, newError(CodeAlpha, ErrInvalidPattern, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithAlphanumeric requires the value to be alphanumerics only.
 func WithAlphanumeric() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeAlphanumeric}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			if !regExpAlphaNumericRegex.Match([]byte(k)) {
-				return ctx, ErrInvalidPattern
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		if !regExpAlphaNumericRegex.MatchString(k) {
+			return ctx, newError(CodeAlphanumeric, ErrInvalidPattern, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithAlphaUnicode requires the value to be standard unicode characters.
 func WithAlphaUnicode() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeAlphaUnicode}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			if !regExpAlphaUnicodeRegex.Match([]byte(k)) {
-				return ctx, ErrInvalidPattern
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		if !regExpAlphaUnicodeRegex.MatchString(k) {
+			return ctx, newError(CodeAlphaUnicode, ErrInvalidPattern, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithAlphanumericUnicode requires the value to be numerics or standard unicode characters.
 func WithAlphanumericUnicode() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeAlphanumericUnicode}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			if !regExpAlphaUnicodeNumericRegex.Match([]byte(k)) {
-				return ctx, ErrInvalidPattern
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		if !regExpAlphaUnicodeNumericRegex.MatchString(k) {
+			return ctx, newError(CodeAlphanumericUnicode, ErrInvalidPattern, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithNumeric requires the value to be numerics (includes the floating point).
 func WithNumeric() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeNumeric}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			if !regExpNumericRegex.Match([]byte(k)) {
-				return ctx, ErrInvalidPattern
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		if !regExpNumericRegex.MatchString(k) {
+			return ctx, newError(CodeNumeric, ErrInvalidPattern, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithHexadecimal 會檢查字串是否為十六進制格式。
@@ -582,11 +774,6 @@ func WithNumeric() Validator {
 
 }*/
 
-// WithLowercase 會檢查字串是否僅有小寫英文字母。
-/*func WithLowercase() {
-
-}*/
-
 // WithUppercase 會檢查字串是否僅有大寫英文字母。
 /*func WithUppercase() {
 
@@ -594,101 +781,101 @@ func WithNumeric() Validator {
 
 // WithRGB requires the value to be a string RGB with `rgb(0,0,0)` format.
 func WithRGB() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeRGB}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			if !regExpRgbRegex.Match([]byte(k)) {
-				return ctx, ErrInvalidPattern
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		if !regExpRgbRegex.MatchString(k) {
+			return ctx, newError(CodeRGB, ErrInvalidPattern, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithRGBA requires the value to be a string RGBA with `rgba(0,0,0,0)` format.
 func WithRGBA() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeRGBA}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			if !regExpRgbaRegex.Match([]byte(k)) {
-				return ctx, ErrInvalidPattern
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		if !regExpRgbaRegex.MatchString(k) {
+			return ctx, newError(CodeRGBA, ErrInvalidPattern, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithHSL requires the value to be a string HSL with `hsl(0,0,0)` format.
 func WithHSL() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeHSL}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			if !regExpHslRegex.Match([]byte(k)) {
-				return ctx, ErrInvalidPattern
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		if !regExpHslRegex.MatchString(k) {
+			return ctx, newError(CodeHSL, ErrInvalidPattern, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithHSLA requires the value to be a string HSLA with `hsla(0,0,0,0)` format.
 func WithHSLA() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeHSLA}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			if !regExpHslaRegex.Match([]byte(k)) {
-				return ctx, ErrInvalidPattern
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		if !regExpHslaRegex.MatchString(k) {
+			return ctx, newError(CodeHSLA, ErrInvalidPattern, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithJSON requires the value to be a valid JSON. It validates via the `json.Valid` function.
 func WithJSON() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeJSON}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			if !json.Valid([]byte(k)) {
-				return ctx, ErrInvalidJSON
-			}
-		case []byte:
-			if !json.Valid(k) {
-				return ctx, ErrInvalidJSON
-			}
-		default:
-			panic(ErrWrongType)
+		var b []byte
+		if value := reflect.ValueOf(v); value.Kind() == reflect.Slice && value.Type().Elem().Kind() == reflect.Uint8 {
+			b = value.Bytes()
+		} else if k, ok := stringOf(v); ok {
+			b = []byte(k)
+		} else {
+			return ctx, wrongType(kindBytes, v)
+		}
+		if !json.Valid(b) {
+			return ctx, newError(CodeJSON, ErrInvalidJSON, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 //
@@ -708,59 +895,59 @@ func WithJSON() Validator {
 
 // WithBase64 requires the value to be a base64 string.
 func WithBase64() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeBase64}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			if !regExpBase64Regex.Match([]byte(k)) {
-				return ctx, ErrInvalidPattern
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		if !regExpBase64Regex.MatchString(k) {
+			return ctx, newError(CodeBase64, ErrInvalidPattern, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithBase64URL requires the value to be a URL base64.
 func WithBase64URL() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeBase64URL}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			if !regExpBase64URLRegex.Match([]byte(k)) {
-				return ctx, ErrInvalidPattern
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		if !regExpBase64URLRegex.MatchString(k) {
+			return ctx, newError(CodeBase64URL, ErrInvalidPattern, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithBitcoinAddress requires the value to be a Bitcoin address.
 func WithBitcoinAddress() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeBitcoinAddress}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			if !regExpBtcAddressRegex.Match([]byte(k)) {
-				return ctx, ErrInvalidPattern
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		if !regExpBtcAddressRegex.MatchString(k) {
+			return ctx, newError(CodeBitcoinAddress, ErrInvalidPattern, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 //
@@ -790,442 +977,432 @@ func WithBitcoinAddress() Validator {
 
 // WithISBN10 requires the value to be a valid ISBN10 string.
 func WithISBN10() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeISBN10}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			if !regExpISBN10Regex.Match([]byte(k)) {
-				return ctx, ErrInvalidPattern
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		if !regExpISBN10Regex.MatchString(k) {
+			return ctx, newError(CodeISBN10, ErrInvalidPattern, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithISBN13 requires the value to be a valid ISBN13 string.
 func WithISBN13() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeISBN13}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			if !regExpISBN13Regex.Match([]byte(k)) {
-				return ctx, ErrInvalidPattern
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		if !regExpISBN13Regex.MatchString(k) {
+			return ctx, newError(CodeISBN13, ErrInvalidPattern, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithUUID requires the value to be a valid UUID string.
 func WithUUID() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeUUID}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			if !regExpUUIDRegex.Match([]byte(k)) {
-				return ctx, ErrInvalidPattern
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		if !regExpUUIDRegex.MatchString(k) {
+			return ctx, newError(CodeUUID, ErrInvalidPattern, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithUUID3 requires the value to be a valid UUID3 string.
 func WithUUID3() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeUUID3}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			if !regExpUUID3Regex.Match([]byte(k)) {
-				return ctx, ErrInvalidPattern
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
 		}
-
-		value := reflect.ValueOf(v)
-		switch value.Kind() {
-		case reflect.String:
-			if !regExpUUID3Regex.Match([]byte(value.String())) {
-				return ctx, ErrInvalidPattern
-			}
-		default:
-			panic(ErrWrongType)
+		if !regExpUUID3Regex.MatchString(k) {
+			return ctx, newError(CodeUUID3, ErrInvalidPattern, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithUUID4 requires the value to be a valid UUID4 string.
 func WithUUID4() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeUUID4}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			if !regExpUUID4Regex.Match([]byte(k)) {
-				return ctx, ErrInvalidPattern
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		if !regExpUUID4Regex.MatchString(k) {
+			return ctx, newError(CodeUUID4, ErrInvalidPattern, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithUUID5 requires the value to be a valid UUID5 string.
 func WithUUID5() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeUUID5}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			if !regExpUUID5Regex.Match([]byte(k)) {
-				return ctx, ErrInvalidPattern
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		if !regExpUUID5Regex.MatchString(k) {
+			return ctx, newError(CodeUUID5, ErrInvalidPattern, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithASCII requires the value to be a valid ASCII characters.
 func WithASCII() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeASCII}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			if !regExpASCIIRegex.Match([]byte(k)) {
-				return ctx, ErrInvalidPattern
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		if !regExpASCIIRegex.MatchString(k) {
+			return ctx, newError(CodeASCII, ErrInvalidPattern, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithASCIIPrintable requires the value to be a valid printable ASCII characters.
 func WithASCIIPrintable() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeASCIIPrintable}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			if !regExpASCIIPrintableRegex.Match([]byte(k)) {
-				return ctx, ErrInvalidPattern
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		if !regExpASCIIPrintableRegex.MatchString(k) {
+			return ctx, newError(CodeASCIIPrintable, ErrInvalidPattern, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithMultiByte requires the value to be multi-byte (e.g. Japanese, Chinese, Symbols).
 func WithMultiByte() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeMultiByte}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			if !regExpMultibyteRegex.Match([]byte(k)) {
-				return ctx, ErrInvalidPattern
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		if !regExpMultibyteRegex.MatchString(k) {
+			return ctx, newError(CodeMultiByte, ErrInvalidPattern, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithDataURI requires the value to be a data URI string.
 func WithDataURI() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeDataURI}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			if !regExpDataURIRegex.Match([]byte(k)) {
-				return ctx, ErrInvalidPattern
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		if !regExpDataURIRegex.MatchString(k) {
+			return ctx, newError(CodeDataURI, ErrInvalidPattern, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithLatitude requires the value to be a valid latitude format.
 func WithLatitude() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeLatitude}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			if !regExpLatitudeRegex.Match([]byte(k)) {
-				return ctx, ErrInvalidPattern
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		if !regExpLatitudeRegex.MatchString(k) {
+			return ctx, newError(CodeLatitude, ErrInvalidPattern, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithLongitude requires the value to be a valid longitude format.
 func WithLongitude() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeLongitude}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			if !regExpLongitudeRegex.Match([]byte(k)) {
-				return ctx, ErrInvalidPattern
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		if !regExpLongitudeRegex.MatchString(k) {
+			return ctx, newError(CodeLongitude, ErrInvalidPattern, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithTCPAddress requires the value TCP address to be resolvable. It validates via the `net.ResolveTCPAddr` function.
 func WithTCPAddress() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeTCPAddress}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			_, err := net.ResolveTCPAddr("tcp", k)
-			if err != nil {
-				return ctx, ErrAddress
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		_, err := net.ResolveTCPAddr("tcp", k)
+		if err != nil {
+			return ctx, newError(CodeTCPAddress, ErrAddress, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithTCPv4Address requires the value TCPv4 address to be resolvable. It validates via the `net.ResolveTCPAddr` function.
 func WithTCPv4Address() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeTCPv4Address}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			_, err := net.ResolveTCPAddr("tcp4", k)
-			if err != nil {
-				return ctx, ErrAddress
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		_, err := net.ResolveTCPAddr("tcp4", k)
+		if err != nil {
+			return ctx, newError(CodeTCPv4Address, ErrAddress, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithTCPv6Address requires the value TCPv6 address to be resolvable. It validates via the `net.ResolveTCPAddr` function.
 func WithTCPv6Address() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeTCPv6Address}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			_, err := net.ResolveTCPAddr("tcp6", k)
-			if err != nil {
-				return ctx, ErrAddress
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		_, err := net.ResolveTCPAddr("tcp6", k)
+		if err != nil {
+			return ctx, newError(CodeTCPv6Address, ErrAddress, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithUDPAddress requires the value UDP address to be resolvable. It validates via the `net.ResolveTCPAddr` function.
 func WithUDPAddress() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeUDPAddress}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			_, err := net.ResolveUDPAddr("udp", k)
-			if err != nil {
-				return ctx, ErrAddress
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		_, err := net.ResolveUDPAddr("udp", k)
+		if err != nil {
+			return ctx, newError(CodeUDPAddress, ErrAddress, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithUDPv4Address requires the value UDPv4 address to be resolvable. It validates via the `net.ResolveTCPAddr` function.
 func WithUDPv4Address() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeUDPv4Address}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			_, err := net.ResolveUDPAddr("udp4", k)
-			if err != nil {
-				return ctx, ErrAddress
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		_, err := net.ResolveUDPAddr("udp4", k)
+		if err != nil {
+			return ctx, newError(CodeUDPv4Address, ErrAddress, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithUDPv6Address requires the value UDPv6 address to be resolvable. It validates via the `net.ResolveTCPAddr` function.
 func WithUDPv6Address() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeUDPv6Address}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			_, err := net.ResolveUDPAddr("udp6", k)
-			if err != nil {
-				return ctx, ErrAddress
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		_, err := net.ResolveUDPAddr("udp6", k)
+		if err != nil {
+			return ctx, newError(CodeUDPv6Address, ErrAddress, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithIPAddress requires the value IP address to be resolvable. It validates via the `net.ResolveIPAddr` function.
 func WithIPAddress() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeIPAddress}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			_, err := net.ResolveIPAddr("ip", k)
-			if err != nil {
-				return ctx, ErrAddress
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		_, err := net.ResolveIPAddr("ip", k)
+		if err != nil {
+			return ctx, newError(CodeIPAddress, ErrAddress, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithIPv4Address requires the value IPv4 address to be resolvable. It validates via the `net.ResolveIPAddr` function.
 //
 // FIX: `::0` is IPv6 but resolvable, WTF GOLANG?
 func WithIPv4Address() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeIPv4Address}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			_, err := net.ResolveIPAddr("ip4", k)
-			if err != nil {
-				return ctx, ErrAddress
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		_, err := net.ResolveIPAddr("ip4", k)
+		if err != nil {
+			return ctx, newError(CodeIPv4Address, ErrAddress, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithIPv6Address requires the value IPv6 address to be resolvable. It validates via the `net.ResolveIPAddr` function.
 func WithIPv6Address() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeIPv6Address}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			_, err := net.ResolveIPAddr("ip6", k)
-			if err != nil {
-				return ctx, ErrAddress
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		_, err := net.ResolveIPAddr("ip6", k)
+		if err != nil {
+			return ctx, newError(CodeIPv6Address, ErrAddress, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithUnixAddress requires the value Unix address to be resolvable. It validates via the `net.ResolveUnixAddr` function.
 func WithUnixAddress() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeUnixAddress}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			_, err := net.ResolveUnixAddr("unix", k)
-			if err != nil {
-				return ctx, ErrAddress
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		_, err := net.ResolveUnixAddr("unix", k)
+		if err != nil {
+			return ctx, newError(CodeUnixAddress, ErrAddress, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithMAC 會驗證一個字串是否為正規的 MAC 地址。
@@ -1235,21 +1412,21 @@ func WithUnixAddress() Validator {
 
 // WithHTML requires the value to be a valid HTML.
 func WithHTML() Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	return describe(description{code: CodeHTML}, func(ctx context.Context, v interface{}) (context.Context, error) {
 		if isNotRequiredAndZeroValue(ctx, v) {
 			return ctx, nil
 		}
+		v = indirect(v)
 
-		switch k := v.(type) {
-		case string:
-			if !regExpHTMLRegex.Match([]byte(k)) {
-				return ctx, ErrInvalidHTML
-			}
-		default:
-			panic(ErrWrongType)
+		k, ok := stringOf(v)
+		if !ok {
+			return ctx, wrongType(kindString, v)
+		}
+		if !regExpHTMLRegex.MatchString(k) {
+			return ctx, newError(CodeHTML, ErrInvalidHTML, v, nil)
 		}
 		return ctx, nil
-	}
+	})
 }
 
 // WithHostname 會驗證指定的主機名稱是否可供解析。
@@ -1258,12 +1435,24 @@ func WithHTML() Validator {
 }*/
 
 // WithCustomError accepts a validator with a custom error. It returns the custom error instead of the native Tavern error when the validator didn't pass it's validation. Useful if you are trying to create custom errors for each validation.
+// The description (e.g. the configuration error, the JSON Schema keywords) of the validator is kept.
 func WithCustomError(validator Validator, err error) Validator {
-	return func(ctx context.Context, v interface{}) (context.Context, error) {
+	d := describeOf(validator)
+	if validator == nil {
+		d.err = invalidConfig("the validator is nil")
+	}
+	return describe(d, func(ctx context.Context, v interface{}) (context.Context, error) {
 		ctx, originalErr := validator(ctx, v)
+		if errors.Is(originalErr, ErrInvalidConfig) {
+			return ctx, originalErr
+		}
 		if originalErr != nil {
+			var verr *ValidationError
+			if errors.As(originalErr, &verr) {
+				return ctx, &ValidationError{Field: verr.Field, Code: verr.Code, Params: verr.Params, Value: verr.Value, Err: err}
+			}
 			return ctx, err
 		}
 		return ctx, nil
-	}
+	})
 }